@@ -0,0 +1,156 @@
+package param
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// cursorVersion is the version of the cursor token format, it is
+// authenticated along with the payload
+const cursorVersion = 1
+
+var (
+	// ErrInvalidCursor is an error for a cursor that is malformed, was
+	// tampered with or was made with another key
+	ErrInvalidCursor = errors.New("Invalid cursor")
+	// ErrCursorExpired is an error for a cursor past its expiry
+	ErrCursorExpired = errors.New("Cursor expired")
+)
+
+type cursorPayload struct {
+	Expires int64           `json:"e,omitempty"`
+	Data    json.RawMessage `json:"d"`
+}
+
+// CursorCodec encrypts and decrypts opaque pagination cursors with
+// AES-GCM. Create it with NewCursorCodec.
+type CursorCodec struct {
+	aead cipher.AEAD
+	// Now returns the current time, nil uses time.Now
+	Now func() time.Time
+}
+
+// NewCursorCodec returns a codec for key, which must be 16, 24 or 32 bytes
+// long. A key of another length is a configuration error and is reported
+// here rather than on every request.
+func NewCursorCodec(key []byte) (*CursorCodec, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &CursorCodec{aead: aead}, nil
+}
+
+func (c *CursorCodec) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// Encode encrypts v, encoded as JSON, into an opaque base64url cursor. A
+// positive ttl makes the cursor expire, a zero ttl keeps it valid for as
+// long as the key is.
+func (c *CursorCodec) Encode(v interface{}, ttl time.Duration) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	payload := cursorPayload{Data: data}
+	if ttl != 0 {
+		payload.Expires = c.now().Add(ttl).Unix()
+	}
+	plain, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := c.aead.NonceSize()
+	out := make([]byte, 1+nonceSize, 1+nonceSize+len(plain)+c.aead.Overhead())
+	out[0] = cursorVersion
+	if _, err := rand.Read(out[1:]); err != nil {
+		return "", err
+	}
+	out = c.aead.Seal(out, out[1:], plain, out[:1])
+	return base64.RawURLEncoding.EncodeToString(out), nil
+}
+
+// Decode decrypts a cursor made by Encode into dst. Failures match
+// ErrInvalidCursor or ErrCursorExpired.
+func (c *CursorCodec) Decode(value string, dst interface{}) error {
+	nonceSize := c.aead.NonceSize()
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(raw) < 1+nonceSize || raw[0] != cursorVersion {
+		return ErrInvalidCursor
+	}
+	plain, err := c.aead.Open(nil, raw[1:1+nonceSize], raw[1+nonceSize:], raw[:1])
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(plain, &payload); err != nil {
+		return ErrInvalidCursor
+	}
+	if payload.Expires != 0 && !c.now().Before(time.Unix(payload.Expires, 0)) {
+		return ErrCursorExpired
+	}
+	if err := json.Unmarshal(payload.Data, dst); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// Query decrypts the cursor in a query parameter into dst, see Decode.
+// Invalid and expired cursors are *Error values matching ErrInvalidParam.
+func (c *CursorCodec) Query(r *http.Request, key string, dst interface{}) error {
+	value, err := QueryString(r, key)
+	if err != nil {
+		return err
+	}
+	if err := c.Decode(value, dst); err != nil {
+		return wrapParam(key, value, err)
+	}
+	return nil
+}
+
+// EncodeCursor encrypts v into a cursor with a one-off codec, see
+// NewCursorCodec and CursorCodec.Encode
+func EncodeCursor(v interface{}, key []byte, ttl time.Duration) (string, error) {
+	c, err := NewCursorCodec(key)
+	if err != nil {
+		return "", err
+	}
+	return c.Encode(v, ttl)
+}
+
+// DecodeCursor decrypts a cursor into dst with a one-off codec, see
+// NewCursorCodec and CursorCodec.Decode
+func DecodeCursor(value string, dst interface{}, key []byte) error {
+	c, err := NewCursorCodec(key)
+	if err != nil {
+		return err
+	}
+	return c.Decode(value, dst)
+}
+
+// QueryCursor decrypts the cursor in a query parameter into dst with a
+// one-off codec, see NewCursorCodec and CursorCodec.Query. A key of the
+// wrong length is returned as a plain error.
+func QueryCursor(r *http.Request, key string, dst interface{}, secret []byte) error {
+	c, err := NewCursorCodec(secret)
+	if err != nil {
+		return err
+	}
+	return c.Query(r, key, dst)
+}
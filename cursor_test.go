@@ -0,0 +1,109 @@
+package param
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+type testCursor struct {
+	ID    int64     `json:"id"`
+	After time.Time `json:"after"`
+}
+
+func TestCursor(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	want := testCursor{ID: 42, After: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	cursor, err := EncodeCursor(want, key, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, _ := EncodeCursor(want, key, time.Minute)
+	if cursor == other {
+		t.Fatal("expected a fresh nonce for every cursor")
+	}
+
+	req := newQueryRequest(t, NewQuery().String("cursor", cursor).Encode())
+
+	var got testCursor
+	if err := QueryCursor(req, "cursor", &got, key); err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestCursorErr(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	cursor, _ := EncodeCursor(testCursor{ID: 1}, key, 0)
+
+	raw, _ := base64.RawURLEncoding.DecodeString(cursor)
+	raw[len(raw)-1] ^= 1
+	tampered := base64.RawURLEncoding.EncodeToString(raw)
+
+	raw[len(raw)-1] ^= 1
+	raw[0] = 2
+	versioned := base64.RawURLEncoding.EncodeToString(raw)
+
+	var dst testCursor
+	for _, value := range []string{"", "not base64!", "AQ", tampered, versioned} {
+		if err := DecodeCursor(value, &dst, key); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("%q: want ErrInvalidCursor, got %v", value, err)
+		}
+	}
+
+	if err := DecodeCursor(cursor, &dst, bytes.Repeat([]byte{2}, 32)); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("want ErrInvalidCursor for another key, got %v", err)
+	}
+
+	stale, _ := EncodeCursor(testCursor{ID: 1}, key, -time.Second)
+	err := QueryCursor(newQueryRequest(t, "cursor="+stale), "cursor", &dst, key)
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrCursorExpired) {
+		t.Fatalf("want ErrCursorExpired, got %v", err)
+	}
+
+	if _, err := EncodeCursor(testCursor{}, []byte("short"), 0); err == nil {
+		t.Fatal("expected an error for a short key")
+	}
+}
+
+func TestCursorCodec(t *testing.T) {
+	if _, err := NewCursorCodec([]byte("short")); err == nil {
+		t.Fatal("expected an error for a short key")
+	}
+
+	c, err := NewCursorCodec(bytes.Repeat([]byte{1}, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Now = func() time.Time { return now }
+
+	cursor, err := c.Encode(testCursor{ID: 7}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got testCursor
+	req := newQueryRequest(t, NewQuery().String("cursor", cursor).Encode())
+	if err := c.Query(req, "cursor", &got); err != nil || got.ID != 7 {
+		t.Fatalf("unexpected cursor %+v, %v", got, err)
+	}
+
+	now = now.Add(time.Minute)
+	err = c.Query(req, "cursor", &got)
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrCursorExpired) {
+		t.Fatalf("want ErrCursorExpired, got %v", err)
+	}
+
+	err = QueryCursor(req, "cursor", &got, []byte("short"))
+	if err == nil || errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want a plain key error, got %v", err)
+	}
+}
@@ -0,0 +1,360 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ErrInexact is an error for a decimal with more places than its scale
+// allows under RoundExact
+var ErrInexact = errors.New("Decimal has too many places")
+
+// RoundingMode tells how a decimal is brought down to fewer places
+type RoundingMode int
+
+// Rounding modes, the zero value rejects decimals that need rounding
+const (
+	RoundExact    RoundingMode = iota // reject with ErrInexact
+	RoundDown                         // toward zero
+	RoundUp                           // away from zero
+	RoundHalfUp                       // to nearest, ties away from zero
+	RoundHalfEven                     // to nearest, ties to even
+)
+
+// Decimal is an exact decimal number, Unscaled divided by 10 to the power
+// of Scale. The zero value is 0.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.Unscaled == nil {
+		return new(big.Int)
+	}
+	return d.Unscaled
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+// Rat returns d as a rational number
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.unscaled(), pow10(d.Scale))
+}
+
+// Cmp compares d and other and returns -1, 0 or +1
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// String returns d with exactly Scale places, such as "19.90"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.unscaled()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.Scale <= 0 {
+		return sign + digits + strings.Repeat("0", -d.Scale)
+	}
+	if len(digits) <= d.Scale {
+		digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-d.Scale] + "." + digits[len(digits)-d.Scale:]
+}
+
+// Round returns d with exactly scale places. Places are added as zeros,
+// places that are not zero are removed following mode.
+func (d Decimal) Round(scale int, mode RoundingMode) (Decimal, error) {
+	u := d.unscaled()
+	if scale >= d.Scale {
+		return Decimal{Unscaled: new(big.Int).Mul(u, pow10(scale-d.Scale)), Scale: scale}, nil
+	}
+
+	div := pow10(d.Scale - scale)
+	q, rem := new(big.Int).QuoRem(u, div, new(big.Int))
+	if rem.Sign() == 0 {
+		return Decimal{Unscaled: q, Scale: scale}, nil
+	}
+
+	// compare twice the remainder with the divisor to find ties
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(div)
+
+	var away bool
+	switch mode {
+	case RoundExact:
+		return Decimal{}, ErrInexact
+	case RoundDown:
+	case RoundUp:
+		away = true
+	case RoundHalfUp:
+		away = cmp >= 0
+	case RoundHalfEven:
+		away = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+	default:
+		return Decimal{}, fmt.Errorf("unknown rounding mode %d", mode)
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(u.Sign())))
+	}
+	return Decimal{Unscaled: q, Scale: scale}, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// DecimalOption configures how decimal parameters are parsed
+type DecimalOption func(*decimalOptions)
+
+type decimalOptions struct {
+	scale       int
+	hasScale    bool
+	mode        RoundingMode
+	maxDigits   int
+	nonNegative bool
+}
+
+// Scale sets the number of places of parsed decimals, shorter values are
+// padded with zeros and longer ones rounded, see Rounding
+func Scale(places int) DecimalOption {
+	return func(o *decimalOptions) {
+		o.scale, o.hasScale = places, true
+	}
+}
+
+// Rounding sets how decimals with more places than their Scale are
+// handled, the default RoundExact rejects them
+func Rounding(mode RoundingMode) DecimalOption {
+	return func(o *decimalOptions) {
+		o.mode = mode
+	}
+}
+
+// MaxDigits rejects decimals with more than n digits in total, counted
+// after rounding to the scale
+func MaxDigits(n int) DecimalOption {
+	return func(o *decimalOptions) {
+		o.maxDigits = n
+	}
+}
+
+// NonNegative rejects negative decimals
+func NonNegative() DecimalOption {
+	return func(o *decimalOptions) {
+		o.nonNegative = true
+	}
+}
+
+// ParseDecimal parses an exact decimal such as "19.99" or "-0.5". Only
+// plain notation is accepted, without exponents, and both sides of the
+// point must have digits. Failures are *strconv.NumError values holding
+// strconv.ErrSyntax, strconv.ErrRange or ErrInexact.
+func ParseDecimal(value string, opts ...DecimalOption) (Decimal, error) {
+	var o decimalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	digits := value
+	neg := false
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		neg, digits = digits[0] == '-', digits[1:]
+	}
+	whole, fraction, dot := strings.Cut(digits, ".")
+	if len(whole) == 0 || (dot && len(fraction) == 0) || !allDigits(whole) || !allDigits(fraction) {
+		return Decimal{}, numError("ParseDecimal", value, strconv.ErrSyntax)
+	}
+
+	u, _ := new(big.Int).SetString(whole+fraction, 10)
+	if neg {
+		u.Neg(u)
+	}
+	out := Decimal{Unscaled: u, Scale: len(fraction)}
+
+	if o.nonNegative && out.Sign() < 0 {
+		return Decimal{}, numError("ParseDecimal", value, strconv.ErrRange)
+	}
+	if o.hasScale {
+		var err error
+		if out, err = out.Round(o.scale, o.mode); err != nil {
+			return Decimal{}, numError("ParseDecimal", value, err)
+		}
+	}
+	if o.maxDigits > 0 && len(new(big.Int).Abs(out.Unscaled).String()) > o.maxDigits {
+		return Decimal{}, numError("ParseDecimal", value, strconv.ErrRange)
+	}
+	return out, nil
+}
+
+func allDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if !isDigit(value[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// PathDecimal returns a path parameter as an exact decimal
+func PathDecimal(r *http.Request, key string, opts ...DecimalOption) (Decimal, error) {
+	value := chi.URLParam(r, key)
+	out, err := ParseDecimal(value, opts...)
+	if err != nil {
+		return Decimal{}, wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// QueryDecimalArray returns a slice of query parameters as exact decimals
+func QueryDecimalArray(r *http.Request, key string, opts ...DecimalOption) ([]Decimal, error) {
+	values, err := QueryStringArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Decimal, len(values))
+	for index, value := range values {
+		v, err := ParseDecimal(unplus(value), opts...)
+		if err != nil {
+			return nil, wrapParam(key, value, err)
+		}
+		out[index] = v
+	}
+	return out, nil
+}
+
+// QueryDecimal returns a query parameter as an exact decimal
+func QueryDecimal(r *http.Request, key string, opts ...DecimalOption) (Decimal, error) {
+	values, err := QueryDecimalArray(r, key, opts...)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return values[0], nil
+}
+
+// parseBigInt parses an integer of any size, accepting the same options
+// as the fixed size getters
+func parseBigInt(value string, opts []IntOption) (*big.Int, error) {
+	number, base := value, 10
+	if len(opts) > 0 {
+		var err error
+		if number, base, err = normalizeInt(value, opts); err != nil {
+			return nil, numError("ParseInt", value, err)
+		}
+	}
+	out, ok := new(big.Int).SetString(number, base)
+	if !ok {
+		return nil, numError("ParseInt", value, strconv.ErrSyntax)
+	}
+	return out, nil
+}
+
+// maxRatExponent bounds the exponent of rational parameters, a huge one
+// would make big.Rat.SetString allocate a huge power of ten
+const maxRatExponent = 1000
+
+// parseBigRat parses a rational number such as "1.5", "3/4" or "1e-3"
+func parseBigRat(value string) (*big.Rat, error) {
+	if i := strings.IndexAny(value, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(value[i+1:])
+		if err != nil {
+			return nil, numError("ParseRat", value, strconv.ErrSyntax)
+		}
+		if exp > maxRatExponent || exp < -maxRatExponent {
+			return nil, numError("ParseRat", value, strconv.ErrRange)
+		}
+	}
+	out, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, numError("ParseRat", value, strconv.ErrSyntax)
+	}
+	return out, nil
+}
+
+// BigInt returns a path parameter as an integer of any size
+func BigInt(r *http.Request, key string, opts ...IntOption) (*big.Int, error) {
+	value := chi.URLParam(r, key)
+	out, err := parseBigInt(value, opts)
+	if err != nil {
+		return nil, wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// BigRat returns a path parameter as a rational number, see big.Rat.SetString
+func BigRat(r *http.Request, key string) (*big.Rat, error) {
+	value := chi.URLParam(r, key)
+	out, err := parseBigRat(value)
+	if err != nil {
+		return nil, wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// QueryBigIntArray returns a slice of query parameters with big integer type
+func QueryBigIntArray(r *http.Request, key string, opts ...IntOption) ([]*big.Int, error) {
+	values, err := QueryStringArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*big.Int, len(values))
+	for index, value := range values {
+		v, err := parseBigInt(value, opts)
+		if err != nil {
+			return nil, wrapParam(key, value, err)
+		}
+		out[index] = v
+	}
+	return out, nil
+}
+
+// QueryBigRatArray returns a slice of query parameters with rational type
+func QueryBigRatArray(r *http.Request, key string) ([]*big.Rat, error) {
+	values, err := QueryStringArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*big.Rat, len(values))
+	for index, value := range values {
+		v, err := parseBigRat(unplus(value))
+		if err != nil {
+			return nil, wrapParam(key, value, err)
+		}
+		out[index] = v
+	}
+	return out, nil
+}
+
+// QueryBigInt returns a query parameter with big integer type
+func QueryBigInt(r *http.Request, key string, opts ...IntOption) (*big.Int, error) {
+	values, err := QueryBigIntArray(r, key, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// QueryBigRat returns a query parameter with rational type
+func QueryBigRat(r *http.Request, key string) (*big.Rat, error) {
+	values, err := QueryBigRatArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// Decimal sets a query parameter with decimal type
+func (q *Query) Decimal(key string, value Decimal) *Query {
+	return q.set(key, []string{value.String()})
+}
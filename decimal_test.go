@@ -0,0 +1,178 @@
+package param
+
+import (
+	"errors"
+	"math/big"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		value string
+		opts  []DecimalOption
+		want  string
+	}{
+		{"19.99", nil, "19.99"},
+		{"-0.5", nil, "-0.5"},
+		{"+7", nil, "7"},
+		{"0.10000000000000000001", nil, "0.10000000000000000001"},
+		{"19.9", []DecimalOption{Scale(2)}, "19.90"},
+		{"1.005", []DecimalOption{Scale(2), Rounding(RoundHalfUp)}, "1.01"},
+		{"1.005", []DecimalOption{Scale(2), Rounding(RoundHalfEven)}, "1.00"},
+		{"1.015", []DecimalOption{Scale(2), Rounding(RoundHalfEven)}, "1.02"},
+		{"-1.001", []DecimalOption{Scale(2), Rounding(RoundUp)}, "-1.01"},
+		{"-1.009", []DecimalOption{Scale(2), Rounding(RoundDown)}, "-1.00"},
+		{"1.250", []DecimalOption{Scale(2)}, "1.25"},
+		{"123456.7", []DecimalOption{Scale(0), Rounding(RoundHalfUp), MaxDigits(6)}, "123457"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDecimal(tt.value, tt.opts...)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if got.String() != tt.want {
+			t.Fatalf("%s: want %s, got %s", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestParseDecimalErr(t *testing.T) {
+	for _, value := range []string{"", "-", ".5", "5.", "1e3", "1.2.3", "NaN", "0x10", "1,5"} {
+		_, err := ParseDecimal(value)
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("%q: want strconv.ErrSyntax, got %v", value, err)
+		}
+	}
+
+	_, err := ParseDecimal("19.999", Scale(2))
+	if !errors.Is(err, ErrInexact) {
+		t.Fatalf("want ErrInexact, got %v", err)
+	}
+
+	_, err = ParseDecimal("-1", NonNegative())
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("want strconv.ErrRange, got %v", err)
+	}
+
+	_, err = ParseDecimal("9999.99", Scale(2), MaxDigits(5))
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("want strconv.ErrRange, got %v", err)
+	}
+}
+
+func TestDecimalRat(t *testing.T) {
+	d, err := ParseDecimal("0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Rat().Cmp(big.NewRat(1, 10)) != 0 {
+		t.Fatalf("want 1/10, got %s", d.Rat())
+	}
+
+	var zero Decimal
+	if zero.String() != "0" || zero.Cmp(Decimal{Unscaled: big.NewInt(0), Scale: 3}) != 0 {
+		t.Fatalf("unexpected zero value %s", zero)
+	}
+
+	if s := (Decimal{Unscaled: big.NewInt(-5), Scale: 3}).String(); s != "-0.005" {
+		t.Fatalf("want -0.005, got %s", s)
+	}
+}
+
+func TestQueryDecimal(t *testing.T) {
+	req := newQueryRequest(t, "min_price=19.99&max_price=abc")
+
+	got, err := QueryDecimal(req, "min_price", Scale(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Unscaled.Int64() != 1999 || got.Scale != 2 {
+		t.Fatalf("want 1999 at scale 2, got %s", got)
+	}
+
+	_, err = QueryDecimal(req, "max_price")
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+
+	q := NewQuery().Decimal("min_price", got)
+	if q.Encode() != "min_price=19.99" {
+		t.Fatalf("unexpected encoding %s", q.Encode())
+	}
+}
+
+func TestPathDecimal(t *testing.T) {
+	req, key := newParamRequest(t, "-2.50")
+
+	got, err := PathDecimal(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.String() != "-2.50" {
+		t.Fatalf("want -2.50, got %s", got)
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	req, key := newParamRequest(t, "123456789012345678901234567890")
+
+	got, err := BigInt(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.String() != "123456789012345678901234567890" {
+		t.Fatalf("unexpected value %s", got)
+	}
+
+	req = newQueryRequest(t, "id=0xFFFFFFFFFFFFFFFFFF&id=12")
+	ids, err := QueryBigIntArray(req, "id", BasePrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 2 || ids[0].Text(16) != "ffffffffffffffffff" || ids[1].Int64() != 12 {
+		t.Fatalf("unexpected values %v", ids)
+	}
+
+	_, err = QueryBigInt(req, "id")
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("want strconv.ErrSyntax, got %v", err)
+	}
+}
+
+func TestQueryBigRat(t *testing.T) {
+	req := newQueryRequest(t, "ratio=3/4&scale="+url.QueryEscape("1e+3"))
+
+	got, err := QueryBigRat(req, "ratio")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Cmp(big.NewRat(3, 4)) != 0 {
+		t.Fatalf("want 3/4, got %s", got)
+	}
+
+	got, err = QueryBigRat(newQueryRequest(t, "scale=1e+3"), "scale")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Cmp(big.NewRat(1000, 1)) != 0 {
+		t.Fatalf("want 1000, got %s", got)
+	}
+}
+
+func TestQueryBigRatExponent(t *testing.T) {
+	_, err := QueryBigRat(newQueryRequest(t, "scale=1e999999"), "scale")
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("want strconv.ErrRange, got %v", err)
+	}
+}
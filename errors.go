@@ -0,0 +1,42 @@
+package param
+
+import "fmt"
+
+// Error describes a parameter that is present but not acceptable. It
+// matches ErrInvalidParam with errors.Is and unwraps to the parsing error,
+// if any, so strconv.ErrSyntax and strconv.ErrRange can still be checked.
+type Error struct {
+	Key    string
+	Value  string
+	Reason string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s %q", ErrInvalidParam.Error(), e.Key)
+	if len(e.Reason) > 0 {
+		msg += ": " + e.Reason
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying parsing error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidParam
+func (e *Error) Is(target error) bool {
+	return target == ErrInvalidParam
+}
+
+func invalidParam(key, value, reason string) *Error {
+	return &Error{Key: key, Value: value, Reason: reason}
+}
+
+func wrapParam(key, value string, err error) *Error {
+	return &Error{Key: key, Value: value, Err: err}
+}
@@ -0,0 +1,245 @@
+package param
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldSchema describes the fields that can be selected from a response type
+type FieldSchema struct {
+	fields map[string]*FieldSchema
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// NewFieldSchema derives a field schema from a struct, fields are named
+// the way encoding/json names them. Types with their own JSON or text
+// encoding are selectable only as a whole.
+func NewFieldSchema(v interface{}) (*FieldSchema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, t)
+	}
+	return schemaOf(t, make(map[reflect.Type]*FieldSchema)), nil
+}
+
+func schemaOf(t reflect.Type, seen map[reflect.Type]*FieldSchema) *FieldSchema {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		reflect.PointerTo(t).Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return nil
+	}
+	if s, ok := seen[t]; ok {
+		return s
+	}
+
+	s := &FieldSchema{fields: make(map[string]*FieldSchema)}
+	seen[t] = s
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		// untagged embedded structs are promoted like encoding/json does
+		if field.Anonymous && len(name) == 0 {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if embedded := schemaOf(ft, seen); embedded != nil {
+					for key, child := range embedded.fields {
+						if _, ok := s.fields[key]; !ok {
+							s.fields[key] = child
+						}
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		s.fields[name] = schemaOf(field.Type, seen)
+	}
+	return s
+}
+
+// Fields is a tree of selected field paths, a nil subtree selects the field
+// as a whole. A nil Fields selects everything.
+type Fields map[string]Fields
+
+// QueryFields returns the field selection of a query parameter such as
+// "fields=id,name,owner.email". Paths are comma separated, may be repeated
+// across keys and are checked against the schema.
+func QueryFields(r *http.Request, key string, schema *FieldSchema) (Fields, error) {
+	values, err := QueryStringArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+	return parseFields(key, values, schema)
+}
+
+// QueryTypedFields returns JSON:API style field selections such as
+// "fields[articles]=title,body", keyed by type. Every type must have a schema.
+func QueryTypedFields(r *http.Request, key string, schemas map[string]*FieldSchema) (map[string]Fields, error) {
+	out := make(map[string]Fields)
+	for name, values := range r.URL.Query() {
+		if !strings.HasPrefix(name, key+"[") || !strings.HasSuffix(name, "]") {
+			continue
+		}
+		typ := name[len(key)+1 : len(name)-1]
+		schema, ok := schemas[typ]
+		if !ok {
+			return nil, invalidParam(name, strings.Join(values, ","), "unknown type "+typ)
+		}
+		fields, err := parseFields(name, values, schema)
+		if err != nil {
+			return nil, err
+		}
+		out[typ] = fields
+	}
+	if len(out) == 0 {
+		return nil, ErrInvalidParam
+	}
+	return out, nil
+}
+
+func parseFields(key string, values []string, schema *FieldSchema) (Fields, error) {
+	out := Fields{}
+	for _, value := range values {
+		for _, path := range strings.Split(value, ",") {
+			if len(path) == 0 {
+				return nil, invalidParam(key, value, "empty field")
+			}
+			if err := out.add(key, path, schema); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f Fields) add(key, path string, schema *FieldSchema) error {
+	node, s := f, schema
+	segments := strings.Split(path, ".")
+	for index, segment := range segments {
+		if s == nil {
+			return invalidParam(key, path, "field "+strings.Join(segments[:index], ".")+" has no subfields")
+		}
+		child, ok := s.fields[segment]
+		if !ok || len(segment) == 0 {
+			return invalidParam(key, path, "unknown field "+path)
+		}
+		last := index == len(segments)-1
+		sub, selected := node[segment]
+		switch {
+		case selected && sub == nil:
+			// the field is already selected as a whole
+			return nil
+		case last:
+			node[segment] = nil
+			return nil
+		case !selected:
+			sub = Fields{}
+			node[segment] = sub
+		}
+		node, s = sub, child
+	}
+	return nil
+}
+
+// Has reports whether a dotted field path is selected
+func (f Fields) Has(path string) bool {
+	if f == nil {
+		return true
+	}
+	node := f
+	for _, segment := range strings.Split(path, ".") {
+		sub, ok := node[segment]
+		if !ok {
+			return false
+		}
+		if sub == nil {
+			return true
+		}
+		node = sub
+	}
+	return true
+}
+
+// Paths returns the selected field paths in dotted form, sorted
+func (f Fields) Paths() []string {
+	var out []string
+	for name, sub := range f {
+		if sub == nil {
+			out = append(out, name)
+			continue
+		}
+		for _, path := range sub.Paths() {
+			out = append(out, name+"."+path)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Prune returns v reduced to the selected fields, ready to be encoded as
+// JSON. Arrays are pruned element by element.
+func (f Fields) Prune(v interface{}) (interface{}, error) {
+	if f == nil {
+		return v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// numbers are kept as json.Number so large identifiers keep their precision
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out interface{}
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return f.prune(out), nil
+}
+
+func (f Fields) prune(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for name, value := range v {
+			sub, ok := f[name]
+			switch {
+			case !ok:
+				delete(v, name)
+			case sub != nil:
+				v[name] = sub.prune(value)
+			}
+		}
+	case []interface{}:
+		for index, value := range v {
+			v[index] = f.prune(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,146 @@
+package param
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fieldsOwner struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+type fieldsBase struct {
+	ID int64 `json:"id"`
+}
+
+type fieldsProject struct {
+	fieldsBase
+	Name     string         `json:"name"`
+	Owner    *fieldsOwner   `json:"owner"`
+	Members  []fieldsOwner  `json:"members"`
+	Parent   *fieldsProject `json:"parent,omitempty"`
+	Created  time.Time      `json:"created"`
+	Secret   string         `json:"-"`
+	internal string
+}
+
+func TestQueryFields(t *testing.T) {
+	schema, err := NewFieldSchema(fieldsProject{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := newQueryRequest(t, "fields=id,owner.email&fields=members.name,parent.owner.id,parent.owner")
+
+	got, err := QueryFields(req, "fields", schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"id", "members.name", "owner.email", "parent.owner"}
+	if !reflect.DeepEqual(want, got.Paths()) {
+		t.Fatalf("want %v, got %v", want, got.Paths())
+	}
+
+	if !got.Has("parent.owner.name") || got.Has("name") {
+		t.Fatalf("unexpected selection %v", got.Paths())
+	}
+}
+
+func TestQueryFieldsErr(t *testing.T) {
+	schema, err := NewFieldSchema(&fieldsProject{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []string{
+		"fields=secret",
+		"fields=internal",
+		"fields=owner.password",
+		"fields=name.first",
+		"fields=created.year",
+		"fields=id,,name",
+		"fields=owner.",
+		"select=id",
+	}
+
+	for _, query := range tests {
+		_, err := QueryFields(newQueryRequest(t, query), "fields", schema)
+		if !errors.Is(err, ErrInvalidParam) {
+			t.Fatalf("%s: want ErrInvalidParam, got %v", query, err)
+		}
+	}
+}
+
+func TestNewFieldSchemaErr(t *testing.T) {
+	_, err := NewFieldSchema(42)
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("want ErrUnsupportedType, got %v", err)
+	}
+}
+
+func TestQueryTypedFields(t *testing.T) {
+	projects, _ := NewFieldSchema(fieldsProject{})
+	owners, _ := NewFieldSchema(fieldsOwner{})
+	schemas := map[string]*FieldSchema{"projects": projects, "owners": owners}
+
+	req := newQueryRequest(t, "fields[projects]=name,owner&fields[owners]=email&page=2")
+
+	got, err := QueryTypedFields(req, "fields", schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]Fields{
+		"projects": {"name": nil, "owner": nil},
+		"owners":   {"email": nil},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	_, err = QueryTypedFields(newQueryRequest(t, "fields[users]=id"), "fields", schemas)
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestFieldsPrune(t *testing.T) {
+	schema, _ := NewFieldSchema(fieldsProject{})
+	req := newQueryRequest(t, "fields=id,owner.email,members.id")
+	fields, err := QueryFields(req, "fields", schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project := fieldsProject{
+		fieldsBase: fieldsBase{ID: 1<<53 + 1},
+		Name:       "chi",
+		Owner:      &fieldsOwner{ID: 2, Email: "a@example.com", Name: "A"},
+		Members:    []fieldsOwner{{ID: 3, Name: "B"}, {ID: 4, Name: "C"}},
+	}
+
+	got, err := fields.Prune(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := json.Marshal(got)
+	want := `{"id":9007199254740993,"members":[{"id":3},{"id":4}],"owner":{"email":"a@example.com"}}`
+	if string(data) != want {
+		t.Fatalf("want %s, got %s", want, data)
+	}
+
+	all, err := Fields(nil).Prune(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(project, all) {
+		t.Fatalf("nil fields should keep the value as is")
+	}
+}
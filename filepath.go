@@ -0,0 +1,131 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrUnsafePath is an error for a file path that could reach outside of
+// its root or hides separators
+var ErrUnsafePath = errors.New("Unsafe file path")
+
+// FilePathOption configures how file path parameters are checked
+type FilePathOption func(*filePathOptions)
+
+type filePathOptions struct {
+	root     fs.FS
+	noHidden bool
+}
+
+// InFS requires the path to name an existing file or directory in fsys,
+// paths that do not exist fail with fs.ErrNotExist
+func InFS(fsys fs.FS) FilePathOption {
+	return func(o *filePathOptions) {
+		o.root = fsys
+	}
+}
+
+// NoHidden rejects paths with a segment starting with a dot, such as
+// ".git/config"
+func NoHidden() FilePathOption {
+	return func(o *filePathOptions) {
+		o.noHidden = true
+	}
+}
+
+// ParseFilePath checks a slash separated relative path and returns it
+// cleaned, in the form accepted by fs.ValidPath. Absolute paths, ".."
+// segments that climb above the root, NUL bytes, backslashes and drive
+// letters are rejected with ErrUnsafePath. The empty path is ".".
+func ParseFilePath(value string, opts ...FilePathOption) (string, error) {
+	var o filePathOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	switch {
+	case strings.HasPrefix(value, "/"):
+		return "", fmt.Errorf("%w: %q is absolute", ErrUnsafePath, value)
+	case strings.ContainsAny(value, "\x00\\"):
+		return "", fmt.Errorf("%w: %q has a NUL byte or backslash", ErrUnsafePath, value)
+	case len(value) >= 2 && value[1] == ':':
+		return "", fmt.Errorf("%w: %q has a drive letter", ErrUnsafePath, value)
+	}
+
+	cleaned := path.Clean(value)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: %q climbs above the root", ErrUnsafePath, value)
+	}
+	if o.noHidden {
+		for _, segment := range strings.Split(cleaned, "/") {
+			if strings.HasPrefix(segment, ".") && segment != "." {
+				return "", fmt.Errorf("%w: %q is hidden", ErrUnsafePath, value)
+			}
+		}
+	}
+	if !fs.ValidPath(cleaned) {
+		return "", fmt.Errorf("%w: %q", ErrUnsafePath, value)
+	}
+
+	if o.root != nil {
+		if _, err := fs.Stat(o.root, cleaned); err != nil {
+			return "", err
+		}
+	}
+	return cleaned, nil
+}
+
+// FilePath returns a path parameter, usually the "*" wildcard, as a file
+// path, see ParseFilePath. Escaped slashes and backslashes are rejected so
+// they can not hide a separator.
+func FilePath(r *http.Request, key string, opts ...FilePathOption) (string, error) {
+	value, escaped := rawPathParam(r, key)
+	unescaped := value
+	if escaped {
+		lower := strings.ToLower(value)
+		if strings.Contains(lower, "%2f") || strings.Contains(lower, "%5c") {
+			return "", wrapParam(key, value, fmt.Errorf("%w: %q has an escaped separator", ErrUnsafePath, value))
+		}
+		var err error
+		if unescaped, err = url.PathUnescape(value); err != nil {
+			return "", wrapParam(key, value, err)
+		}
+	}
+	out, err := ParseFilePath(unescaped, opts...)
+	if err != nil {
+		return "", wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// FilePathSegments returns the segments of a file path parameter, see
+// FilePath. The root "." has no segments.
+func FilePathSegments(r *http.Request, key string, opts ...FilePathOption) ([]string, error) {
+	p, err := FilePath(r, key, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if p == "." {
+		return []string{}, nil
+	}
+	return strings.Split(p, "/"), nil
+}
+
+// ParseSegments parses each segment with parse, such as the year and
+// month of "2022/05" with strconv.Atoi
+func ParseSegments[T any](segments []string, parse func(string) (T, error)) ([]T, error) {
+	out := make([]T, len(segments))
+	for index, segment := range segments {
+		v, err := parse(segment)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", index, err)
+		}
+		out[index] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,104 @@
+package param
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseFilePath(t *testing.T) {
+	tests := map[string]string{
+		"":                 ".",
+		"css/site.css":     "css/site.css",
+		"a/./b//c/":        "a/b/c",
+		"a/../b":           "b",
+		"docs/..":          ".",
+		"..foo/bar":        "..foo/bar",
+		"files/report.pdf": "files/report.pdf",
+	}
+
+	for value, want := range tests {
+		got, err := ParseFilePath(value)
+		if err != nil {
+			t.Fatalf("%q: %v", value, err)
+		}
+
+		if got != want {
+			t.Fatalf("%q: want %q, got %q", value, want, got)
+		}
+	}
+}
+
+func TestParseFilePathErr(t *testing.T) {
+	for _, value := range []string{"..", "../etc/passwd", "a/../../b", "/etc/passwd", "a\x00b", `..\windows`, "C:/windows"} {
+		if _, err := ParseFilePath(value); !errors.Is(err, ErrUnsafePath) {
+			t.Fatalf("%q: want ErrUnsafePath, got %v", value, err)
+		}
+	}
+
+	if _, err := ParseFilePath("a/.git/config", NoHidden()); !errors.Is(err, ErrUnsafePath) {
+		t.Fatalf("want ErrUnsafePath, got %v", err)
+	}
+
+	fsys := fstest.MapFS{"css/site.css": {Data: []byte("body{}")}}
+	if _, err := ParseFilePath("css/site.css", InFS(fsys)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseFilePath("css/missing.css", InFS(fsys)); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("want fs.ErrNotExist, got %v", err)
+	}
+}
+
+func wildcardFilePath(r *http.Request) (string, error) {
+	return FilePath(r, "*")
+}
+
+func TestFilePath(t *testing.T) {
+	got, err := serveRequest(t, "/static/*", "/static/css/my%20site.css", wildcardFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "css/my site.css" {
+		t.Fatalf("unexpected path %q", got)
+	}
+
+	got, err = serveRequest(t, "/static/*", "/static/100%25.txt", wildcardFilePath)
+	if err != nil || got != "100%.txt" {
+		t.Fatalf("unexpected path %q, %v", got, err)
+	}
+
+	for _, target := range []string{"/static/..%2F..%2Fetc%2Fpasswd", "/static/a%5Cb", "/static/%2e%2e/secret", "/static/a%00b"} {
+		_, err := serveRequest(t, "/static/*", target, wildcardFilePath)
+		if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrUnsafePath) {
+			t.Fatalf("%s: want ErrUnsafePath, got %v", target, err)
+		}
+	}
+}
+
+func TestFilePathSegments(t *testing.T) {
+	req, key := newParamRequest(t, "2022/05/31")
+
+	segments, err := FilePathSegments(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ParseSegments(segments, strconv.Atoi)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, []int{2022, 5, 31}) {
+		t.Fatalf("unexpected segments %v", got)
+	}
+
+	if _, err := ParseSegments([]string{"2022", "may"}, strconv.Atoi); !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("want strconv.ErrSyntax, got %v", err)
+	}
+}
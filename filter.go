@@ -0,0 +1,441 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// FilterType is the type of a filterable field, operands are converted
+// with the same rules as the matching Query* getter
+type FilterType int
+
+const (
+	// FilterString accepts any operand
+	FilterString FilterType = iota
+	// FilterInt converts operands like QueryInt64
+	FilterInt
+	// FilterFloat converts operands like QueryFloat64
+	FilterFloat
+	// FilterBool converts operands like QueryBool
+	FilterBool
+	// FilterTime converts operands like QueryTime
+	FilterTime
+)
+
+// FilterSchema declares the filterable fields and their types
+type FilterSchema map[string]FilterType
+
+// FilterOp is a comparison operator
+type FilterOp string
+
+// Comparison operators, written in their canonical FIQL form
+const (
+	FilterEq  FilterOp = "=="
+	FilterNe  FilterOp = "!="
+	FilterLt  FilterOp = "=lt="
+	FilterLe  FilterOp = "=le="
+	FilterGt  FilterOp = "=gt="
+	FilterGe  FilterOp = "=ge="
+	FilterIn  FilterOp = "=in="
+	FilterOut FilterOp = "=out="
+
+	FilterContains   FilterOp = "=contains="
+	FilterStartsWith FilterOp = "=startswith="
+	FilterEndsWith   FilterOp = "=endswith="
+)
+
+var filterAliases = map[string]FilterOp{
+	"<":  FilterLt,
+	"<=": FilterLe,
+	">":  FilterGt,
+	">=": FilterGe,
+}
+
+// FilterExpr is a node of a parsed filter expression, one of FilterAnd,
+// FilterOr, FilterNot or FilterComparison
+type FilterExpr interface {
+	filterExpr()
+}
+
+// FilterAnd matches when all of its expressions match
+type FilterAnd struct {
+	Exprs []FilterExpr
+}
+
+// FilterOr matches when any of its expressions match
+type FilterOr struct {
+	Exprs []FilterExpr
+}
+
+// FilterNot matches when its expression does not match
+type FilterNot struct {
+	Expr FilterExpr
+}
+
+// FilterComparison compares a field with typed operands, Values hold
+// string, int64, float64, bool or time.Time depending on the field type
+// and nil for null. Pos is the byte offset of the comparison in the expression.
+type FilterComparison struct {
+	Field  string
+	Type   FilterType
+	Op     FilterOp
+	Values []interface{}
+	Pos    int
+}
+
+func (FilterAnd) filterExpr()        {}
+func (FilterOr) filterExpr()         {}
+func (FilterNot) filterExpr()        {}
+func (FilterComparison) filterExpr() {}
+
+// FilterError is a syntax or type error at a byte offset of a filter expression
+type FilterError struct {
+	Pos int
+	Msg string
+	Err error
+}
+
+func (e *FilterError) Error() string {
+	msg := fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the operand conversion error, if any
+func (e *FilterError) Unwrap() error {
+	return e.Err
+}
+
+// Default limits of filter expressions, see MaxDepth and MaxLength
+const (
+	DefaultFilterDepth  = 32
+	DefaultFilterLength = 4096
+)
+
+// ErrFilterTooComplex is an error for a filter expression past the nesting
+// or length limit
+var ErrFilterTooComplex = errors.New("Filter expression is too complex")
+
+// FilterOption configures the limits of filter expressions
+type FilterOption func(*filterOptions)
+
+type filterOptions struct {
+	maxDepth  int
+	maxLength int
+}
+
+// MaxDepth limits how deep groups can be nested, by default DefaultFilterDepth
+func MaxDepth(depth int) FilterOption {
+	return func(o *filterOptions) {
+		o.maxDepth = depth
+	}
+}
+
+// MaxLength limits the length of an expression in bytes, by default
+// DefaultFilterLength
+func MaxLength(length int) FilterOption {
+	return func(o *filterOptions) {
+		o.maxLength = length
+	}
+}
+
+// FilterLimits returns the nesting depth and length limits set by opts, so
+// parsers of other filter syntaxes apply the same limits
+func FilterLimits(opts ...FilterOption) (maxDepth, maxLength int) {
+	o := filterOptions{maxDepth: DefaultFilterDepth, maxLength: DefaultFilterLength}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.maxDepth, o.maxLength
+}
+
+// QueryFilter returns a query parameter parsed as an RSQL/FIQL expression
+// such as "status==open;priority=gt=3,owner=in=(a,b)" and type-checked
+// against the schema. Errors carry a *FilterError with the offending position.
+func QueryFilter(r *http.Request, key string, schema FilterSchema, opts ...FilterOption) (FilterExpr, error) {
+	value, err := QueryString(r, key)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := ParseFilter(value, schema, opts...)
+	if err != nil {
+		return nil, wrapParam(key, value, err)
+	}
+	return expr, nil
+}
+
+// ParseFilter parses an RSQL/FIQL expression. ";" binds tighter than ","
+// and parentheses group expressions. Errors are of type *FilterError,
+// expressions past the limits also match ErrFilterTooComplex.
+func ParseFilter(expr string, schema FilterSchema, opts ...FilterOption) (FilterExpr, error) {
+	maxDepth, maxLength := FilterLimits(opts...)
+	if len(expr) > maxLength {
+		return nil, &FilterError{Pos: maxLength, Msg: fmt.Sprintf("longer than %d bytes", maxLength), Err: ErrFilterTooComplex}
+	}
+	p := &filterParser{input: expr, schema: schema, maxDepth: maxDepth}
+	out, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.input) {
+		return nil, p.errorf(p.pos, "unexpected %q", p.input[p.pos])
+	}
+	return out, nil
+}
+
+type filterParser struct {
+	input    string
+	pos      int
+	schema   FilterSchema
+	depth    int
+	maxDepth int
+}
+
+func (p *filterParser) errorf(pos int, format string, args ...interface{}) *FilterError {
+	return &FilterError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *filterParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *filterParser) or() (FilterExpr, error) {
+	var exprs []FilterExpr
+	for {
+		expr, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return FilterOr{Exprs: exprs}, nil
+}
+
+func (p *filterParser) and() (FilterExpr, error) {
+	var exprs []FilterExpr
+	for {
+		expr, err := p.constraint()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if p.peek() != ';' {
+			break
+		}
+		p.pos++
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return FilterAnd{Exprs: exprs}, nil
+}
+
+func (p *filterParser) constraint() (FilterExpr, error) {
+	if p.peek() != '(' {
+		return p.comparison()
+	}
+	start := p.pos
+	if p.depth >= p.maxDepth {
+		return nil, &FilterError{Pos: start, Msg: fmt.Sprintf("nested deeper than %d groups", p.maxDepth), Err: ErrFilterTooComplex}
+	}
+	p.pos++
+	p.depth++
+	expr, err := p.or()
+	p.depth--
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != ')' {
+		return nil, p.errorf(start, "unclosed group")
+	}
+	p.pos++
+	return expr, nil
+}
+
+func isFilterReserved(c byte) bool {
+	return strings.IndexByte(`"'();,=!~<>`, c) >= 0
+}
+
+func (p *filterParser) comparison() (FilterExpr, error) {
+	start := p.pos
+	for p.pos < len(p.input) && !isFilterReserved(p.input[p.pos]) && p.input[p.pos] != ' ' {
+		p.pos++
+	}
+	field := p.input[start:p.pos]
+	if len(field) == 0 {
+		return nil, p.errorf(start, "expected field")
+	}
+	typ, ok := p.schema[field]
+	if !ok {
+		return nil, p.errorf(start, "unknown field %s", field)
+	}
+
+	opPos := p.pos
+	op, err := p.operator()
+	if err != nil {
+		return nil, err
+	}
+	if !FilterOpSupported(typ, op) {
+		return nil, p.errorf(opPos, "operator %s is not supported for field %s", op, field)
+	}
+
+	var (
+		values    []interface{}
+		positions []int
+		list      = p.peek() == '('
+	)
+	if list {
+		p.pos++
+		for {
+			pos := p.pos
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			values, positions = append(values, value), append(positions, pos)
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf(p.pos, "expected ')'")
+		}
+		p.pos++
+	} else {
+		pos := p.pos
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values, positions = append(values, value), append(positions, pos)
+	}
+
+	multi := op == FilterIn || op == FilterOut
+	if multi && !list {
+		return nil, p.errorf(opPos, "operator %s expects a list of values", op)
+	}
+	if !multi && len(values) != 1 {
+		return nil, p.errorf(opPos, "operator %s expects a single value", op)
+	}
+
+	for index, value := range values {
+		v, err := convertFilterValue(typ, value.(string))
+		if err != nil {
+			return nil, &FilterError{Pos: positions[index], Msg: "invalid value for field " + field, Err: err}
+		}
+		values[index] = v
+	}
+	return FilterComparison{Field: field, Type: typ, Op: op, Values: values, Pos: start}, nil
+}
+
+func (p *filterParser) operator() (FilterOp, error) {
+	start := p.pos
+	rest := p.input[p.pos:]
+	switch {
+	case strings.HasPrefix(rest, "=="):
+		p.pos += 2
+		return FilterEq, nil
+	case strings.HasPrefix(rest, "!="):
+		p.pos += 2
+		return FilterNe, nil
+	case strings.HasPrefix(rest, "<="), strings.HasPrefix(rest, ">="):
+		p.pos += 2
+		return filterAliases[rest[:2]], nil
+	case strings.HasPrefix(rest, "<"), strings.HasPrefix(rest, ">"):
+		p.pos++
+		return filterAliases[rest[:1]], nil
+	case strings.HasPrefix(rest, "="):
+		end := strings.IndexByte(rest[1:], '=')
+		if end > 0 {
+			op := FilterOp(rest[:end+2])
+			switch op {
+			case FilterLt, FilterLe, FilterGt, FilterGe, FilterIn, FilterOut,
+				FilterContains, FilterStartsWith, FilterEndsWith:
+				p.pos += len(op)
+				return op, nil
+			}
+			return "", p.errorf(start, "unknown operator %s", op)
+		}
+	}
+	return "", p.errorf(start, "expected operator")
+}
+
+func (p *filterParser) value() (string, error) {
+	start := p.pos
+	quote := p.peek()
+	if quote == '"' || quote == '\'' {
+		var b strings.Builder
+		for p.pos++; p.pos < len(p.input); p.pos++ {
+			c := p.input[p.pos]
+			switch {
+			case c == '\\' && p.pos+1 < len(p.input):
+				p.pos++
+				b.WriteByte(p.input[p.pos])
+			case c == quote:
+				p.pos++
+				return b.String(), nil
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", p.errorf(start, "unterminated string")
+	}
+
+	for p.pos < len(p.input) && !isFilterReserved(p.input[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", p.errorf(start, "expected value")
+	}
+	return p.input[start:p.pos], nil
+}
+
+// FilterOpSupported reports whether an operator can be applied to a field
+// type. Booleans only support equality and the string matching operators
+// only apply to strings.
+func FilterOpSupported(typ FilterType, op FilterOp) bool {
+	switch op {
+	case FilterEq, FilterNe, FilterIn, FilterOut:
+		return true
+	case FilterLt, FilterLe, FilterGt, FilterGe:
+		return typ != FilterBool
+	case FilterContains, FilterStartsWith, FilterEndsWith:
+		return typ == FilterString
+	}
+	return false
+}
+
+// ConvertFilterValue converts an operand to the Go type of a field type
+func ConvertFilterValue(typ FilterType, value string) (interface{}, error) {
+	return convertFilterValue(typ, value)
+}
+
+func convertFilterValue(typ FilterType, value string) (interface{}, error) {
+	switch typ {
+	case FilterInt:
+		return parseInt64(value)
+	case FilterFloat:
+		return parseQueryFloat64(value)
+	case FilterBool:
+		return strconv.ParseBool(value)
+	case FilterTime:
+		return parseQueryTime(value)
+	}
+	return value, nil
+}
@@ -0,0 +1,153 @@
+package param
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var filterSchema = FilterSchema{
+	"status":   FilterString,
+	"priority": FilterInt,
+	"owner":    FilterString,
+	"score":    FilterFloat,
+	"archived": FilterBool,
+	"created":  FilterTime,
+}
+
+func TestQueryFilter(t *testing.T) {
+	req := newQueryRequest(t, "filter="+url.QueryEscape("status==open;priority=gt=3,owner=in=(a,'b c')"))
+
+	got, err := QueryFilter(req, "filter", filterSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := FilterOr{Exprs: []FilterExpr{
+		FilterAnd{Exprs: []FilterExpr{
+			FilterComparison{Field: "status", Type: FilterString, Op: FilterEq, Values: []interface{}{"open"}, Pos: 0},
+			FilterComparison{Field: "priority", Type: FilterInt, Op: FilterGt, Values: []interface{}{int64(3)}, Pos: 13},
+		}},
+		FilterComparison{Field: "owner", Type: FilterString, Op: FilterIn, Values: []interface{}{"a", "b c"}, Pos: 27},
+	}}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %#v, got %#v", want, got)
+	}
+}
+
+func TestParseFilterGroups(t *testing.T) {
+	got, err := ParseFilter(`archived==false;(score<=1.5,created>2024-01-02T03:04:05+02:00)`, filterSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created := time.Date(2024, 1, 2, 1, 4, 5, 0, time.UTC)
+	and, ok := got.(FilterAnd)
+	if !ok || len(and.Exprs) != 2 {
+		t.Fatalf("want and of two expressions, got %#v", got)
+	}
+	or, ok := and.Exprs[1].(FilterOr)
+	if !ok || len(or.Exprs) != 2 {
+		t.Fatalf("want or of two expressions, got %#v", and.Exprs[1])
+	}
+
+	score := or.Exprs[0].(FilterComparison)
+	if score.Op != FilterLe || score.Values[0] != 1.5 {
+		t.Fatalf("want score=le=1.5, got %#v", score)
+	}
+	since := or.Exprs[1].(FilterComparison)
+	if since.Op != FilterGt || !since.Values[0].(time.Time).Equal(created) {
+		t.Fatalf("want created=gt=%v, got %#v", created, since)
+	}
+}
+
+func TestParseFilterStringOps(t *testing.T) {
+	got, err := ParseFilter("owner=startswith=adm", filterSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := FilterComparison{Field: "owner", Type: FilterString, Op: FilterStartsWith, Values: []interface{}{"adm"}}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %#v, got %#v", want, got)
+	}
+
+	_, err = ParseFilter("priority=contains=1", filterSchema)
+	if err == nil {
+		t.Fatal("expected error for string operator on int field")
+	}
+}
+
+func TestParseFilterErr(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{"", 0},
+		{"status", 6},
+		{"status=", 6},
+		{"status==", 8},
+		{"password==x", 0},
+		{"status=like=x", 6},
+		{"priority==high", 10},
+		{"priority=in=(1,two)", 15},
+		{"archived=gt=true", 8},
+		{"status=in=open", 6},
+		{"status==(a,b)", 6},
+		{"(status==open", 0},
+		{"status==open)", 12},
+		{"status=='open", 8},
+		{"status==open;", 13},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseFilter(tt.expr, filterSchema)
+
+		var filterErr *FilterError
+		if !errors.As(err, &filterErr) {
+			t.Fatalf("%q: want *FilterError, got %v", tt.expr, err)
+		}
+		if filterErr.Pos != tt.pos {
+			t.Fatalf("%q: want position %d, got %d (%v)", tt.expr, tt.pos, filterErr.Pos, err)
+		}
+	}
+}
+
+func TestQueryFilterErr(t *testing.T) {
+	_, err := QueryFilter(newQueryRequest(t, "q=x"), "filter", filterSchema)
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+
+	_, err = QueryFilter(newQueryRequest(t, "filter=priority==high"), "filter", filterSchema)
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("want syntax error, got %v", err)
+	}
+}
+
+func TestQueryFilterLimits(t *testing.T) {
+	deep := strings.Repeat("(", 400000) + "status==open" + strings.Repeat(")", 400000)
+	req := newQueryRequest(t, "filter="+url.QueryEscape(deep))
+
+	_, err := QueryFilter(req, "filter", filterSchema, MaxLength(len(deep)))
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrFilterTooComplex) {
+		t.Fatalf("want ErrFilterTooComplex, got %v", err)
+	}
+
+	_, err = QueryFilter(req, "filter", filterSchema)
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrFilterTooComplex) {
+		t.Fatalf("want ErrFilterTooComplex, got %v", err)
+	}
+
+	nested := strings.Repeat("(", 3) + "status==open" + strings.Repeat(")", 3)
+	if _, err := ParseFilter(nested, filterSchema, MaxDepth(3)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseFilter(nested, filterSchema, MaxDepth(2)); !errors.Is(err, ErrFilterTooComplex) {
+		t.Fatalf("want ErrFilterTooComplex, got %v", err)
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/oceanicdev/chi-param
 
-go 1.17
+go 1.18
 
 require github.com/go-chi/chi/v5 v5.0.7
@@ -0,0 +1,386 @@
+package param
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ErrMalformedID is an error for an identifier that does not follow its format
+var ErrMalformedID = errors.New("Malformed identifier")
+
+func malformed(kind, value string) error {
+	return fmt.Errorf("%w: %q is not a valid %s", ErrMalformedID, value, kind)
+}
+
+// UUID is an RFC 9562 UUID
+type UUID [16]byte
+
+// String returns the UUID in canonical lower case form
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// Version returns the version of the UUID
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// gregorianOffset is the number of 100ns intervals between the start of
+// the Gregorian calendar and the Unix epoch
+const gregorianOffset = 122192928000000000
+
+// Time returns the time embedded in version 1, 6 and 7 UUIDs
+func (u UUID) Time() (time.Time, bool) {
+	var ticks uint64
+	switch u.Version() {
+	case 1:
+		ticks = uint64(u[6]&0x0f)<<56 | uint64(u[7])<<48 | uint64(u[4])<<40 | uint64(u[5])<<32 |
+			uint64(u[0])<<24 | uint64(u[1])<<16 | uint64(u[2])<<8 | uint64(u[3])
+	case 6:
+		ticks = uint64(u[0])<<52 | uint64(u[1])<<44 | uint64(u[2])<<36 | uint64(u[3])<<28 |
+			uint64(u[4])<<20 | uint64(u[5])<<12 | uint64(u[6]&0x0f)<<8 | uint64(u[7])
+	case 7:
+		ms := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 | uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+		return time.UnixMilli(int64(ms)).UTC(), true
+	default:
+		return time.Time{}, false
+	}
+	since := int64(ticks) - gregorianOffset
+	// a time.Duration only spans 292 years, the 60 bit clock about 3600
+	return time.Unix(since/1e7, since%1e7*100).UTC(), true
+}
+
+// UUIDOption configures how UUID parameters are parsed
+type UUIDOption func(*uuidOptions)
+
+type uuidOptions struct {
+	versions  []int
+	canonical bool
+}
+
+// UUIDVersions accepts only UUIDs of the given versions
+func UUIDVersions(versions ...int) UUIDOption {
+	return func(o *uuidOptions) {
+		o.versions = versions
+	}
+}
+
+// CanonicalUUID accepts only the canonical lower case hyphenated form
+func CanonicalUUID() UUIDOption {
+	return func(o *uuidOptions) {
+		o.canonical = true
+	}
+}
+
+// ParseUUID parses a UUID of version 1 to 8 with the RFC 9562 variant. Next
+// to the hyphenated form it accepts upper case, braces, a "urn:uuid:"
+// prefix and the 32 digit form without hyphens, unless CanonicalUUID is
+// given.
+func ParseUUID(value string, opts ...UUIDOption) (UUID, error) {
+	var o uuidOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := value
+	if !o.canonical {
+		if len(s) == 38 && s[0] == '{' && s[37] == '}' {
+			s = s[1:37]
+		} else if len(s) == 45 && strings.EqualFold(s[:9], "urn:uuid:") {
+			s = s[9:]
+		}
+	}
+
+	var digits string
+	switch {
+	case len(s) == 36 && s[8] == '-' && s[13] == '-' && s[18] == '-' && s[23] == '-':
+		digits = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case len(s) == 32 && !o.canonical:
+		digits = s
+	default:
+		return UUID{}, malformed("UUID", value)
+	}
+	if o.canonical && strings.ToLower(digits) != digits {
+		return UUID{}, malformed("UUID", value)
+	}
+
+	var out UUID
+	if _, err := hex.Decode(out[:], []byte(digits)); err != nil {
+		return UUID{}, malformed("UUID", value)
+	}
+	if v := out.Version(); v < 1 || v > 8 || out[8]&0xc0 != 0x80 {
+		return UUID{}, malformed("UUID", value)
+	}
+	if o.versions != nil {
+		allowed := false
+		for _, v := range o.versions {
+			allowed = allowed || v == out.Version()
+		}
+		if !allowed {
+			return UUID{}, fmt.Errorf("UUID version %d is not accepted", out.Version())
+		}
+	}
+	return out, nil
+}
+
+// crockford is the Crockford base 32 alphabet used by ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID is a lexicographically sortable identifier, see github.com/ulid/spec
+type ULID [16]byte
+
+// String returns the ULID in canonical upper case form
+func (u ULID) String() string {
+	var b [26]byte
+	n := new(big.Int).SetBytes(u[:])
+	m := new(big.Int)
+	base := big.NewInt(32)
+	for i := len(b) - 1; i >= 0; i-- {
+		n.QuoRem(n, base, m)
+		b[i] = crockford[m.Int64()]
+	}
+	return string(b[:])
+}
+
+// Time returns the time embedded in the ULID
+func (u ULID) Time() time.Time {
+	ms := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 | uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+	return time.UnixMilli(int64(ms)).UTC()
+}
+
+// ParseULID parses a 26 character ULID, case insensitive
+func ParseULID(value string) (ULID, error) {
+	// 26 characters hold 130 bits, the first may only use the lower 3
+	if len(value) != 26 || value[0] > '7' {
+		return ULID{}, malformed("ULID", value)
+	}
+	n := new(big.Int)
+	for i := 0; i < len(value); i++ {
+		d := strings.IndexByte(crockford, value[i]&^0x20)
+		if value[i] >= '0' && value[i] <= '9' {
+			d = int(value[i] - '0')
+		}
+		if d < 0 {
+			return ULID{}, malformed("ULID", value)
+		}
+		n.Lsh(n, 5).Or(n, big.NewInt(int64(d)))
+	}
+	var out ULID
+	n.FillBytes(out[:])
+	return out, nil
+}
+
+// base62 is the alphabet used by KSUIDs
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// ksuidEpoch is the start of KSUID time, 2014-05-13 16:53:20 UTC
+const ksuidEpoch = 1400000000
+
+// KSUID is a K-sortable identifier, see github.com/segmentio/ksuid
+type KSUID [20]byte
+
+// String returns the KSUID in its 27 character form
+func (k KSUID) String() string {
+	var b [27]byte
+	n := new(big.Int).SetBytes(k[:])
+	m := new(big.Int)
+	base := big.NewInt(62)
+	for i := len(b) - 1; i >= 0; i-- {
+		n.QuoRem(n, base, m)
+		b[i] = base62[m.Int64()]
+	}
+	return string(b[:])
+}
+
+// Time returns the time embedded in the KSUID, with second precision
+func (k KSUID) Time() time.Time {
+	s := uint32(k[0])<<24 | uint32(k[1])<<16 | uint32(k[2])<<8 | uint32(k[3])
+	return time.Unix(int64(s)+ksuidEpoch, 0).UTC()
+}
+
+// ParseKSUID parses a 27 character KSUID
+func ParseKSUID(value string) (KSUID, error) {
+	if len(value) != 27 {
+		return KSUID{}, malformed("KSUID", value)
+	}
+	n := new(big.Int)
+	base := big.NewInt(62)
+	for i := 0; i < len(value); i++ {
+		d := strings.IndexByte(base62, value[i])
+		if d < 0 {
+			return KSUID{}, malformed("KSUID", value)
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(d)))
+	}
+	var out KSUID
+	if n.BitLen() > len(out)*8 {
+		return KSUID{}, malformed("KSUID", value)
+	}
+	n.FillBytes(out[:])
+	return out, nil
+}
+
+// TwitterEpoch is the epoch of Twitter Snowflake IDs
+var TwitterEpoch = time.UnixMilli(1288834974657).UTC()
+
+// Snowflake is a Twitter style Snowflake ID, 41 bits of milliseconds since
+// an epoch, 10 bits of worker and 12 bits of sequence
+type Snowflake uint64
+
+// String returns the ID in decimal form
+func (s Snowflake) String() string {
+	return strconv.FormatUint(uint64(s), 10)
+}
+
+// Time returns the time embedded in the ID, counted from epoch
+func (s Snowflake) Time(epoch time.Time) time.Time {
+	return epoch.Add(time.Duration(s>>22) * time.Millisecond)
+}
+
+// Worker returns the worker that generated the ID
+func (s Snowflake) Worker() int {
+	return int(s >> 12 & 0x3ff)
+}
+
+// Sequence returns the sequence number of the ID
+func (s Snowflake) Sequence() int {
+	return int(s & 0xfff)
+}
+
+// ParseSnowflake parses a Snowflake ID in decimal form, which has to fit
+// in 63 bits
+func ParseSnowflake(value string) (Snowflake, error) {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || v < 0 || value[0] == '+' {
+		return 0, malformed("Snowflake ID", value)
+	}
+	return Snowflake(v), nil
+}
+
+// pathID returns a path parameter parsed with parse
+func pathID[T any](r *http.Request, key string, parse func(string) (T, error)) (T, error) {
+	value := chi.URLParam(r, key)
+	out, err := parse(value)
+	if err != nil {
+		var zero T
+		return zero, wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// queryIDArray returns query parameters parsed with parse
+func queryIDArray[T any](r *http.Request, key string, parse func(string) (T, error)) ([]T, error) {
+	values, err := QueryStringArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]T, len(values))
+	for index, value := range values {
+		v, err := parse(value)
+		if err != nil {
+			return nil, wrapParam(key, value, err)
+		}
+		out[index] = v
+	}
+	return out, nil
+}
+
+func uuidParser(opts []UUIDOption) func(string) (UUID, error) {
+	return func(value string) (UUID, error) {
+		return ParseUUID(value, opts...)
+	}
+}
+
+// PathUUID returns a path parameter as a UUID
+func PathUUID(r *http.Request, key string, opts ...UUIDOption) (UUID, error) {
+	return pathID(r, key, uuidParser(opts))
+}
+
+// PathULID returns a path parameter as a ULID
+func PathULID(r *http.Request, key string) (ULID, error) {
+	return pathID(r, key, ParseULID)
+}
+
+// PathKSUID returns a path parameter as a KSUID
+func PathKSUID(r *http.Request, key string) (KSUID, error) {
+	return pathID(r, key, ParseKSUID)
+}
+
+// PathSnowflake returns a path parameter as a Snowflake ID
+func PathSnowflake(r *http.Request, key string) (Snowflake, error) {
+	return pathID(r, key, ParseSnowflake)
+}
+
+// QueryUUIDArray returns a slice of query parameters with UUID type
+func QueryUUIDArray(r *http.Request, key string, opts ...UUIDOption) ([]UUID, error) {
+	return queryIDArray(r, key, uuidParser(opts))
+}
+
+// QueryULIDArray returns a slice of query parameters with ULID type
+func QueryULIDArray(r *http.Request, key string) ([]ULID, error) {
+	return queryIDArray(r, key, ParseULID)
+}
+
+// QueryKSUIDArray returns a slice of query parameters with KSUID type
+func QueryKSUIDArray(r *http.Request, key string) ([]KSUID, error) {
+	return queryIDArray(r, key, ParseKSUID)
+}
+
+// QuerySnowflakeArray returns a slice of query parameters with Snowflake ID type
+func QuerySnowflakeArray(r *http.Request, key string) ([]Snowflake, error) {
+	return queryIDArray(r, key, ParseSnowflake)
+}
+
+// QueryUUID returns a query parameter with UUID type
+func QueryUUID(r *http.Request, key string, opts ...UUIDOption) (UUID, error) {
+	values, err := QueryUUIDArray(r, key, opts...)
+	if err != nil {
+		return UUID{}, err
+	}
+	return values[0], nil
+}
+
+// QueryULID returns a query parameter with ULID type
+func QueryULID(r *http.Request, key string) (ULID, error) {
+	values, err := QueryULIDArray(r, key)
+	if err != nil {
+		return ULID{}, err
+	}
+	return values[0], nil
+}
+
+// QueryKSUID returns a query parameter with KSUID type
+func QueryKSUID(r *http.Request, key string) (KSUID, error) {
+	values, err := QueryKSUIDArray(r, key)
+	if err != nil {
+		return KSUID{}, err
+	}
+	return values[0], nil
+}
+
+// QuerySnowflake returns a query parameter with Snowflake ID type
+func QuerySnowflake(r *http.Request, key string) (Snowflake, error) {
+	values, err := QuerySnowflakeArray(r, key)
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
@@ -0,0 +1,171 @@
+package param
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseUUID(t *testing.T) {
+	want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	tests := []struct {
+		value   string
+		version int
+	}{
+		{"c232ab00-9414-11ec-b3c8-9f6bdeced846", 1},
+		{"1EC9414C-232A-6B00-B3C8-9F6BDECED846", 6},
+		{"{017f22e2-79b0-7cc3-98c4-dc0c0c07398f}", 7},
+		{"urn:uuid:017f22e2-79b0-7cc3-98c4-dc0c0c07398f", 7},
+		{"017f22e279b07cc398c4dc0c0c07398f", 7},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseUUID(tt.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if got.Version() != tt.version {
+			t.Fatalf("%s: want version %d, got %d", tt.value, tt.version, got.Version())
+		}
+
+		ts, ok := got.Time()
+		if !ok || !ts.Equal(want) {
+			t.Fatalf("%s: want %s, got %s", tt.value, want, ts)
+		}
+	}
+
+	// the largest version 1 clock is past the range of a time.Duration
+	last, _ := ParseUUID("ffffffff-ffff-1fff-8fff-ffffffffffff")
+	if ts, ok := last.Time(); !ok || !ts.Equal(time.Date(5236, 3, 31, 21, 21, 0, 684697500, time.UTC)) {
+		t.Fatalf("unexpected time %s", ts)
+	}
+
+	u, err := ParseUUID("919108f7-52d1-4320-9bac-f847db4148a8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := u.Time(); ok || u.String() != "919108f7-52d1-4320-9bac-f847db4148a8" {
+		t.Fatalf("unexpected UUID %s", u)
+	}
+}
+
+func TestParseUUIDErr(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"00000000-0000-0000-0000-000000000000",
+		"919108f7-52d1-4320-1bac-f847db4148a8",
+		"919108f7-52d1-9320-9bac-f847db4148a8",
+		"919108f7052d1-4320-9bac-f847db4148a8",
+		"919108f7-52d1-4320-9bac-f847db4148ag",
+	} {
+		_, err := ParseUUID(value)
+		if !errors.Is(err, ErrMalformedID) {
+			t.Fatalf("%q: want ErrMalformedID, got %v", value, err)
+		}
+	}
+
+	for _, value := range []string{"919108F7-52D1-4320-9BAC-F847DB4148A8", "919108f752d143209bacf847db4148a8"} {
+		if _, err := ParseUUID(value, CanonicalUUID()); !errors.Is(err, ErrMalformedID) {
+			t.Fatalf("%q: want ErrMalformedID, got %v", value, err)
+		}
+	}
+
+	if _, err := ParseUUID("919108f7-52d1-4320-9bac-f847db4148a8", UUIDVersions(7)); err == nil {
+		t.Fatal("expected version 4 to be rejected")
+	}
+}
+
+func TestParseULID(t *testing.T) {
+	got, err := ParseULID("01arz3ndektsv4rrffq69g5fav")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.String() != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Fatalf("unexpected ULID %s", got)
+	}
+
+	if got.Time().UnixMilli() != 1469922850259 {
+		t.Fatalf("unexpected time %s", got.Time())
+	}
+
+	for _, value := range []string{"", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU", "01ARZ3NDEKTSV4RRFFQ69G5FA"} {
+		if _, err := ParseULID(value); !errors.Is(err, ErrMalformedID) {
+			t.Fatalf("%q: want ErrMalformedID, got %v", value, err)
+		}
+	}
+}
+
+func TestParseKSUID(t *testing.T) {
+	got, err := ParseKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.String() != "0ujtsYcgvSTl8PAuAdqWYSMnLOv" {
+		t.Fatalf("unexpected KSUID %s", got)
+	}
+
+	want := time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC)
+	if !got.Time().Equal(want) {
+		t.Fatalf("want %s, got %s", want, got.Time())
+	}
+
+	for _, value := range []string{"", "0ujtsYcgvSTl8PAuAdqWYSMnLO-", "zzzzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, err := ParseKSUID(value); !errors.Is(err, ErrMalformedID) {
+			t.Fatalf("%q: want ErrMalformedID, got %v", value, err)
+		}
+	}
+}
+
+func TestParseSnowflake(t *testing.T) {
+	id := Snowflake(1000<<22 | 5<<12 | 7)
+
+	got, err := ParseSnowflake(id.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Worker() != 5 || got.Sequence() != 7 || !got.Time(TwitterEpoch).Equal(TwitterEpoch.Add(time.Second)) {
+		t.Fatalf("unexpected parts of %s", got)
+	}
+
+	for _, value := range []string{"", "-1", "+1", "abc", "9223372036854775808"} {
+		if _, err := ParseSnowflake(value); !errors.Is(err, ErrMalformedID) {
+			t.Fatalf("%q: want ErrMalformedID, got %v", value, err)
+		}
+	}
+}
+
+func TestPathUUID(t *testing.T) {
+	req, key := newParamRequest(t, "not-a-uuid")
+
+	_, err := PathUUID(req, key)
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrMalformedID) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Key != key {
+		t.Fatalf("want *Error for %s, got %v", key, err)
+	}
+}
+
+func TestQueryULIDArray(t *testing.T) {
+	req := newQueryRequest(t, "id=01ARZ3NDEKTSV4RRFFQ69G5FAV&id=01BX5ZZKBKACTAV9WEVGEMMVRZ")
+
+	got, err := QueryULIDArray(req, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || got[1].String() != "01BX5ZZKBKACTAV9WEVGEMMVRZ" {
+		t.Fatalf("unexpected values %v", got)
+	}
+
+	if _, err := QuerySnowflake(req, "id"); !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ISODuration is an ISO 8601 duration such as "P1Y2M3DT4H5M6.5S". Years,
+// months, weeks and days are calendar units and are kept apart from the
+// fixed length clock part.
+type ISODuration struct {
+	Years  int
+	Months int
+	Weeks  int
+	Days   int
+	Clock  time.Duration
+}
+
+// AddTo returns t moved forward by the duration
+func (d ISODuration) AddTo(t time.Time) time.Time {
+	return t.AddDate(d.Years, d.Months, d.Weeks*7+d.Days).Add(d.Clock)
+}
+
+// SubFrom returns t moved back by the duration
+func (d ISODuration) SubFrom(t time.Time) time.Time {
+	return t.Add(-d.Clock).AddDate(-d.Years, -d.Months, -(d.Weeks*7 + d.Days))
+}
+
+// calendarUnits maps ISO 8601 calendar units to relative time units
+var calendarUnits = map[byte]byte{'Y': 'y', 'M': 'M', 'W': 'w', 'D': 'd'}
+
+// ParseISODuration parses an ISO 8601 duration. Only the clock units may
+// have a fraction, calendar units must be whole numbers.
+func ParseISODuration(value string) (ISODuration, error) {
+	var out ISODuration
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return out, fmt.Errorf("invalid ISO 8601 duration %q", value)
+	}
+
+	// units must appear once each and in this order
+	const order = "YMWDHMS"
+	rest, inTime, units, last := value[1:], false, 0, -1
+	for len(rest) > 0 {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				return ISODuration{}, fmt.Errorf("invalid ISO 8601 duration %q", value)
+			}
+			inTime, rest = true, rest[1:]
+			continue
+		}
+
+		end := 0
+		for end < len(rest) && (rest[end] >= '0' && rest[end] <= '9' || rest[end] == '.' || rest[end] == ',') {
+			end++
+		}
+		if end == 0 || end == len(rest) {
+			return ISODuration{}, fmt.Errorf("invalid ISO 8601 duration %q", value)
+		}
+		number, unit := strings.Replace(rest[:end], ",", ".", 1), rest[end]
+		rest = rest[end+1:]
+		units++
+
+		index := strings.IndexByte(order, unit)
+		if inTime && unit == 'M' {
+			index = strings.LastIndexByte(order, unit)
+		}
+		if index < 0 || index <= last || (index > 3) != inTime {
+			return ISODuration{}, fmt.Errorf("invalid ISO 8601 duration unit %q in %q", unit, value)
+		}
+		last = index
+
+		if inTime {
+			if number == "." {
+				return ISODuration{}, fmt.Errorf("invalid ISO 8601 duration %q", value)
+			}
+			var scale time.Duration
+			switch unit {
+			case 'H':
+				scale = time.Hour
+			case 'M':
+				scale = time.Minute
+			case 'S':
+				scale = time.Second
+			}
+			// scaled exactly, a float64 rounds up to 2^63 near the bound
+			v, ok := scaleDecimal(number, uint64(scale), math.MaxInt64-uint64(out.Clock))
+			if !ok {
+				return ISODuration{}, fmt.Errorf("ISO 8601 duration %q: %w", value, strconv.ErrRange)
+			}
+			out.Clock += time.Duration(v)
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return ISODuration{}, err
+		}
+		// calendar units are bounded like relative times, to about 292 years
+		if span := relativeUnits[calendarUnits[unit]]; n > int(math.MaxInt64/span) {
+			return ISODuration{}, fmt.Errorf("%w: ISO 8601 duration %q is out of range", ErrInvalidParam, value)
+		}
+		switch unit {
+		case 'Y':
+			out.Years = n
+		case 'M':
+			out.Months = n
+		case 'W':
+			out.Weeks = n
+		case 'D':
+			out.Days = n
+		}
+	}
+	if units == 0 {
+		return ISODuration{}, fmt.Errorf("invalid ISO 8601 duration %q", value)
+	}
+	return out, nil
+}
+
+// ErrSpanTooLong is an error for a time range longer than allowed
+var ErrSpanTooLong = errors.New("Time range is too long")
+
+// TimeRange is a half-open time range [Start, End)
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the range
+func (r TimeRange) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Contains reports whether t is within the range
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// String returns the range as an ISO 8601 interval
+func (r TimeRange) String() string {
+	return formatTime(r.Start) + "/" + formatTime(r.End)
+}
+
+// ParseInterval parses an ISO 8601 interval in "start/end", "start/duration"
+// or "duration/end" form, "--" may be used instead of "/". The start must
+// be before the end and the range may span at most maxSpan, a zero maxSpan
+// disables the check.
+func ParseInterval(value string, maxSpan time.Duration) (TimeRange, error) {
+	first, second, ok := strings.Cut(value, "/")
+	if !ok {
+		first, second, ok = strings.Cut(value, "--")
+	}
+	if !ok {
+		return TimeRange{}, fmt.Errorf("invalid ISO 8601 interval %q", value)
+	}
+
+	var (
+		out TimeRange
+		err error
+	)
+	switch {
+	case strings.HasPrefix(first, "P") && strings.HasPrefix(second, "P"):
+		return TimeRange{}, fmt.Errorf("ISO 8601 interval %q has no fixed end", value)
+	case strings.HasPrefix(second, "P"):
+		if out.Start, err = parseTime(first); err != nil {
+			return TimeRange{}, err
+		}
+		d, err := ParseISODuration(second)
+		if err != nil {
+			return TimeRange{}, err
+		}
+		out.End = d.AddTo(out.Start)
+	case strings.HasPrefix(first, "P"):
+		if out.End, err = parseTime(second); err != nil {
+			return TimeRange{}, err
+		}
+		d, err := ParseISODuration(first)
+		if err != nil {
+			return TimeRange{}, err
+		}
+		out.Start = d.SubFrom(out.End)
+	default:
+		if out.Start, err = parseTime(first); err != nil {
+			return TimeRange{}, err
+		}
+		if out.End, err = parseTime(second); err != nil {
+			return TimeRange{}, err
+		}
+	}
+	if err := checkTimeRange(out, maxSpan); err != nil {
+		return TimeRange{}, err
+	}
+	return out, nil
+}
+
+func checkTimeRange(r TimeRange, maxSpan time.Duration) error {
+	if !r.Start.Before(r.End) {
+		return fmt.Errorf("start %s is not before end %s", formatTime(r.Start), formatTime(r.End))
+	}
+	if maxSpan > 0 && r.Duration() > maxSpan {
+		return fmt.Errorf("%w: %s exceeds %s", ErrSpanTooLong, r.Duration(), maxSpan)
+	}
+	return nil
+}
+
+// PathInterval returns a path parameter as an ISO 8601 interval, an
+// escaped "/" is accepted as well as "--"
+func PathInterval(r *http.Request, key string, maxSpan time.Duration) (TimeRange, error) {
+	value := chi.URLParam(r, key)
+	unescaped, err := url.PathUnescape(value)
+	if err != nil {
+		return TimeRange{}, wrapParam(key, value, err)
+	}
+	out, err := ParseInterval(unescaped, maxSpan)
+	if err != nil {
+		return TimeRange{}, wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// QueryInterval returns a query parameter as an ISO 8601 interval
+func QueryInterval(r *http.Request, key string, maxSpan time.Duration) (TimeRange, error) {
+	value, err := QueryString(r, key)
+	if err != nil {
+		return TimeRange{}, err
+	}
+	out, err := ParseInterval(unplus(value), maxSpan)
+	if err != nil {
+		return TimeRange{}, wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// QueryTimeRange returns a time range from a pair of query parameters such
+// as "from" and "to", parsed like QueryTime with the given options
+func QueryTimeRange(r *http.Request, fromKey, toKey string, maxSpan time.Duration, opts ...TimeOption) (TimeRange, error) {
+	var (
+		out TimeRange
+		err error
+	)
+	if out.Start, err = QueryTime(r, fromKey, opts...); err != nil {
+		return TimeRange{}, wrapQueryErr(r, fromKey, err)
+	}
+	if out.End, err = QueryTime(r, toKey, opts...); err != nil {
+		return TimeRange{}, wrapQueryErr(r, toKey, err)
+	}
+	if err := checkTimeRange(out, maxSpan); err != nil {
+		value, _ := queryValue(r, toKey)
+		return TimeRange{}, wrapParam(toKey, value, err)
+	}
+	return out, nil
+}
+
+// wrapQueryErr adds the key to a parsing error, missing parameters keep
+// returning ErrInvalidParam as is
+func wrapQueryErr(r *http.Request, key string, err error) error {
+	if err == ErrInvalidParam {
+		return err
+	}
+	value, _ := queryValue(r, key)
+	return wrapParam(key, value, err)
+}
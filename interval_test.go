@@ -0,0 +1,172 @@
+package param
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ISODuration
+	}{
+		{"P1DT2H", ISODuration{Days: 1, Clock: 2 * time.Hour}},
+		{"PT1H30M", ISODuration{Clock: 90 * time.Minute}},
+		{"PT0,5S", ISODuration{Clock: 500 * time.Millisecond}},
+		{"P1Y2M3W4DT5H6M7.25S", ISODuration{Years: 1, Months: 2, Weeks: 3, Days: 4, Clock: 5*time.Hour + 6*time.Minute + 7250*time.Millisecond}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseISODuration(tt.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("%s: want %+v, got %+v", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestParseISODurationErr(t *testing.T) {
+	for _, value := range []string{"", "P", "PT", "P1DT", "1D", "P1H", "PT1D", "P1.5D", "P1D1D", "PT1S2M", "P-1D", "PT99999999999H"} {
+		if _, err := ParseISODuration(value); err == nil {
+			t.Fatalf("%q: expected error", value)
+		}
+	}
+
+	for _, value := range []string{"PT99999999999H", "PT9223372036.854775808S", "PT1H9223368436.854775808S"} {
+		if _, err := ParseISODuration(value); !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("%q: want strconv.ErrRange, got %v", value, err)
+		}
+	}
+
+	if got, err := ParseISODuration("PT9223372036.854775807S"); err != nil || got.Clock != math.MaxInt64 {
+		t.Fatalf("want the largest duration, got %v, %v", got.Clock, err)
+	}
+
+	for _, value := range []string{"P99999999999999W", "P999999999D", "P9999999M", "P999999Y"} {
+		if _, err := ParseISODuration(value); !errors.Is(err, ErrInvalidParam) {
+			t.Fatalf("%q: want ErrInvalidParam, got %v", value, err)
+		}
+	}
+
+	if _, err := ParseISODuration("P100Y"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  TimeRange
+	}{
+		{"2024-01-01T00:00:00Z/2024-02-01T00:00:00Z", TimeRange{start, start.AddDate(0, 1, 0)}},
+		{"2024-01-01T00:00:00Z/PT1H", TimeRange{start, start.Add(time.Hour)}},
+		{"P1D/2024-01-02T00:00:00Z", TimeRange{start, start.AddDate(0, 0, 1)}},
+		{"2024-01-01T00:00:00Z--P1M", TimeRange{start, start.AddDate(0, 1, 0)}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseInterval(tt.value, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+			t.Fatalf("%s: want %s, got %s", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestParseIntervalErr(t *testing.T) {
+	tests := []string{
+		"2024-01-01T00:00:00Z",
+		"P1D/P2D",
+		"2024-02-01T00:00:00Z/2024-01-01T00:00:00Z",
+		"2024-01-01T00:00:00Z/2024-01-01T00:00:00Z",
+		"yesterday/today",
+		"2024-01-01T00:00:00Z/P1X",
+	}
+
+	for _, value := range tests {
+		if _, err := ParseInterval(value, 0); err == nil {
+			t.Fatalf("%q: expected error", value)
+		}
+	}
+
+	_, err := ParseInterval("2024-01-01T00:00:00Z/P1Y", 31*24*time.Hour)
+	if !errors.Is(err, ErrSpanTooLong) {
+		t.Fatalf("want ErrSpanTooLong, got %v", err)
+	}
+}
+
+func TestPathInterval(t *testing.T) {
+	req, key := newParamRequest(t, "2024-01-01T00:00:00Z%2FPT1H")
+
+	got, err := PathInterval(req, key, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Duration() != time.Hour {
+		t.Fatalf("want %s, got %s", time.Hour, got.Duration())
+	}
+
+	req, key = newParamRequest(t, "2024-01-01T00:00:00Z--PT2H")
+	_, err = PathInterval(req, key, time.Hour)
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrSpanTooLong) {
+		t.Fatalf("want ErrSpanTooLong, got %v", err)
+	}
+}
+
+func TestQueryInterval(t *testing.T) {
+	req := newQueryRequest(t, "period=2024-01-01T00:00:00+01:00/PT1H")
+
+	got, err := QueryInterval(req, "period", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)
+	if !got.Start.Equal(want) || !got.Contains(want.Add(time.Minute)) || got.Contains(want.Add(time.Hour)) {
+		t.Fatalf("unexpected range %s", got)
+	}
+
+	_, err = QueryInterval(req, "missing", 0)
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestQueryTimeRange(t *testing.T) {
+	req := newQueryRequest(t, "from=2024-01-01T00:00:00Z&to=2024-01-08T00:00:00Z")
+
+	got, err := QueryTimeRange(req, "from", "to", 7*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Duration() != 7*24*time.Hour {
+		t.Fatalf("want 7 days, got %s", got.Duration())
+	}
+
+	_, err = QueryTimeRange(req, "to", "from", 0)
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+
+	_, err = QueryTimeRange(req, "from", "to", 24*time.Hour)
+	if !errors.Is(err, ErrSpanTooLong) {
+		t.Fatalf("want ErrSpanTooLong, got %v", err)
+	}
+
+	_, err = QueryTimeRange(newQueryRequest(t, "from=now&to=2024-01-08T00:00:00Z"), "from", "to", 0)
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
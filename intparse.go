@@ -0,0 +1,177 @@
+package param
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IntOption configures how integer parameters are parsed, by default only
+// plain base 10 numbers are accepted
+type IntOption func(*intOptions)
+
+type intOptions struct {
+	prefix      bool
+	underscores bool
+	group       rune
+	decimal     rune
+}
+
+// BasePrefix accepts the 0x, 0o and 0b prefixes for base 16, 8 and 2 numbers.
+// Numbers without a prefix stay base 10, a leading zero does not make a
+// number octal.
+func BasePrefix() IntOption {
+	return func(o *intOptions) {
+		o.prefix = true
+	}
+}
+
+// Underscores accepts underscores between digits as in Go literals, such
+// as "1_000_000"
+func Underscores() IntOption {
+	return func(o *intOptions) {
+		o.underscores = true
+	}
+}
+
+// Grouping accepts base 10 numbers written with locale digit grouping, such
+// as "1.234.567" or "1 234 567". Groups after the first must have three
+// digits. A zero decimal rune disables decimals, otherwise a fraction of
+// zeros such as the ",00" in "1.234,00" is accepted and dropped. A space
+// group also matches no-break and narrow no-break spaces.
+func Grouping(group, decimal rune) IntOption {
+	return func(o *intOptions) {
+		o.group = group
+		o.decimal = decimal
+	}
+}
+
+// parseIntOpts parses a signed integer of the given bit size, a zero bit
+// size is the size of int
+func parseIntOpts(value string, bits int, opts []IntOption) (int64, error) {
+	if len(opts) == 0 {
+		return strconv.ParseInt(value, 10, bits)
+	}
+	number, base, err := normalizeInt(value, opts)
+	if err != nil {
+		return 0, numError("ParseInt", value, err)
+	}
+	v, err := strconv.ParseInt(number, base, bits)
+	return v, renumber(err, value)
+}
+
+// parseUintOpts parses an unsigned integer of the given bit size
+func parseUintOpts(value string, bits int, opts []IntOption) (uint64, error) {
+	if len(opts) == 0 {
+		return strconv.ParseUint(value, 10, bits)
+	}
+	number, base, err := normalizeInt(value, opts)
+	if err != nil {
+		return 0, numError("ParseUint", value, err)
+	}
+	v, err := strconv.ParseUint(number, base, bits)
+	return v, renumber(err, value)
+}
+
+// atoiOpts is strconv.Atoi with options
+func atoiOpts(value string, opts []IntOption) (int, error) {
+	if len(opts) == 0 {
+		return strconv.Atoi(value)
+	}
+	v, err := parseIntOpts(value, 0, opts)
+	return int(v), err
+}
+
+// renumber reports the value as given rather than the normalized one
+func renumber(err error, value string) error {
+	if e, ok := err.(*strconv.NumError); ok {
+		e.Num = value
+	}
+	return err
+}
+
+// normalizeInt rewrites value into a form strconv parses with the
+// returned base
+func normalizeInt(value string, opts []IntOption) (string, int, error) {
+	var o intOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	sign, digits := "", value
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	if o.prefix && len(digits) > 2 && digits[0] == '0' && strings.ContainsRune("xXoObB", rune(digits[1])) {
+		if !o.underscores && strings.Contains(digits, "_") {
+			return "", 0, strconv.ErrSyntax
+		}
+		// base 0 checks the underscores against the Go rules
+		return sign + digits, 0, nil
+	}
+
+	if o.underscores {
+		var ok bool
+		if digits, ok = stripUnderscores(digits); !ok {
+			return "", 0, strconv.ErrSyntax
+		}
+	}
+
+	if o.group != 0 || o.decimal != 0 {
+		var ok bool
+		if digits, ok = ungroup(digits, o.group, o.decimal); !ok {
+			return "", 0, strconv.ErrSyntax
+		}
+	}
+	return sign + digits, 10, nil
+}
+
+// stripUnderscores removes underscores that sit between two digits
+func stripUnderscores(value string) (string, bool) {
+	if !strings.Contains(value, "_") {
+		return value, true
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '_' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i == 0 || i == len(value)-1 || !isDigit(value[i-1]) || !isDigit(value[i+1]) {
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// ungroup removes digit grouping and a zero fraction from value
+func ungroup(value string, group, decimal rune) (string, bool) {
+	if group == ' ' {
+		value = strings.NewReplacer("\u00a0", " ", "\u202f", " ").Replace(value)
+	}
+
+	if decimal != 0 {
+		if i := strings.IndexRune(value, decimal); i >= 0 {
+			fraction := value[i+len(string(decimal)):]
+			if len(fraction) == 0 || strings.Trim(fraction, "0") != "" {
+				return "", false
+			}
+			value = value[:i]
+		}
+	}
+
+	if group == 0 || !strings.ContainsRune(value, group) {
+		return value, true
+	}
+	parts := strings.Split(value, string(group))
+	for index, part := range parts {
+		if len(part) == 0 || len(part) > 3 || (index > 0 && len(part) != 3) {
+			return "", false
+		}
+	}
+	return strings.Join(parts, ""), true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,117 @@
+package param
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseIntOpts(t *testing.T) {
+	tests := []struct {
+		value string
+		opts  []IntOption
+		want  int64
+	}{
+		{"0x1F", []IntOption{BasePrefix()}, 31},
+		{"-0b101", []IntOption{BasePrefix()}, -5},
+		{"0o17", []IntOption{BasePrefix()}, 15},
+		{"010", []IntOption{BasePrefix()}, 10},
+		{"0x_FF_FF", []IntOption{BasePrefix(), Underscores()}, 65535},
+		{"1_000_000", []IntOption{Underscores()}, 1000000},
+		{"1.234.567", []IntOption{Grouping('.', ',')}, 1234567},
+		{"-1.234,00", []IntOption{Grouping('.', ',')}, -1234},
+		{"1 234 567", []IntOption{Grouping(' ', ',')}, 1234567},
+		{"1 234", []IntOption{Grouping(' ', ',')}, 1234},
+		{"1,234", []IntOption{Grouping(',', '.')}, 1234},
+		{"1234", []IntOption{Grouping(',', '.')}, 1234},
+	}
+
+	for _, tt := range tests {
+		got, err := parseIntOpts(tt.value, 64, tt.opts)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.value, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("%q: want %d, got %d", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestParseIntOptsErr(t *testing.T) {
+	tests := []struct {
+		value string
+		opts  []IntOption
+	}{
+		{"0x1F", nil},
+		{"1_000", nil},
+		{"0x_1F", []IntOption{BasePrefix()}},
+		{"_1000", []IntOption{Underscores()}},
+		{"1__000", []IntOption{Underscores()}},
+		{"1000_", []IntOption{Underscores()}},
+		{"1.23.456", []IntOption{Grouping('.', ',')}},
+		{"1234.567", []IntOption{Grouping('.', ',')}},
+		{".123", []IntOption{Grouping('.', ',')}},
+		{"1.234,5", []IntOption{Grouping('.', ',')}},
+		{"1.234,", []IntOption{Grouping('.', ',')}},
+		{"1 234", []IntOption{Grouping('.', ',')}},
+	}
+
+	for _, tt := range tests {
+		_, err := parseIntOpts(tt.value, 64, tt.opts)
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("%q: want strconv.ErrSyntax, got %v", tt.value, err)
+		}
+
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) && numErr.Num != tt.value {
+			t.Fatalf("%q: error reports %q", tt.value, numErr.Num)
+		}
+	}
+
+	_, err := parseUintOpts("0x1FF", 8, []IntOption{BasePrefix()})
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("want strconv.ErrRange, got %v", err)
+	}
+}
+
+func TestInt64Options(t *testing.T) {
+	req, key := newParamRequest(t, "0xff")
+
+	if _, err := Int64(req, key); !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("want strconv.ErrSyntax without options, got %v", err)
+	}
+
+	got, err := Int64(req, key, BasePrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != 255 {
+		t.Fatalf("want 255, got %d", got)
+	}
+}
+
+func TestQueryIntArrayOptions(t *testing.T) {
+	req := newQueryRequest(t, "n=1.000&n=2.500.000&n=7")
+
+	got, err := QueryIntArray(req, "n", Grouping('.', ','))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{1000, 2500000, 7}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	u, err := QueryUint16(newQueryRequest(t, "n=0b1_0000"), "n", BasePrefix(), Underscores())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u != 16 {
+		t.Fatalf("want 16, got %d", u)
+	}
+}
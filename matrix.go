@@ -0,0 +1,226 @@
+package param
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Segment is a path segment with its RFC 3986 matrix parameters, such as
+// "a;color=red;size=L"
+type Segment struct {
+	Name   string
+	Params url.Values
+}
+
+// ParseSegment splits an escaped path segment into its name and matrix
+// parameters. A parameter without "=" has an empty value.
+func ParseSegment(raw string) (Segment, error) {
+	return parseSegment(raw, true)
+}
+
+func parseSegment(raw string, escaped bool) (Segment, error) {
+	unescape := func(value string) (string, error) {
+		if !escaped {
+			return value, nil
+		}
+		return url.PathUnescape(value)
+	}
+
+	parts := strings.Split(raw, ";")
+	name, err := unescape(parts[0])
+	if err != nil {
+		return Segment{}, err
+	}
+	out := Segment{Name: name, Params: url.Values{}}
+	for _, part := range parts[1:] {
+		if len(part) == 0 {
+			continue
+		}
+		k, v, _ := strings.Cut(part, "=")
+		if k, err = unescape(k); err != nil {
+			return Segment{}, err
+		}
+		if v, err = unescape(v); err != nil {
+			return Segment{}, err
+		}
+		out.Params.Add(k, v)
+	}
+	return out, nil
+}
+
+// rawPathParam returns a path parameter and whether it is still escaped,
+// chi routes on the raw path when the request has one
+func rawPathParam(r *http.Request, key string) (string, bool) {
+	return chi.URLParam(r, key), len(r.URL.RawPath) > 0
+}
+
+// SegmentMatrix returns the segments of a path parameter, usually the "*"
+// wildcard, with their matrix parameters. A trailing slash does not add an
+// empty segment.
+func SegmentMatrix(r *http.Request, key string) ([]Segment, error) {
+	value, escaped := rawPathParam(r, key)
+	value = strings.TrimSuffix(value, "/")
+	if len(value) == 0 {
+		return []Segment{}, nil
+	}
+	parts := strings.Split(value, "/")
+	out := make([]Segment, len(parts))
+	for index, part := range parts {
+		s, err := parseSegment(part, escaped)
+		if err != nil {
+			return nil, wrapParam(key, value, err)
+		}
+		out[index] = s
+	}
+	return out, nil
+}
+
+// Segments returns the names of the segments of a path parameter, see
+// SegmentMatrix. Use ParseSegments for typed segments.
+func Segments(r *http.Request, key string) ([]string, error) {
+	segments, err := SegmentMatrix(r, key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(segments))
+	for index, s := range segments {
+		out[index] = s.Name
+	}
+	return out, nil
+}
+
+// matrixValues returns the values of a matrix parameter of a path
+// parameter. A parameter with several segments, such as the "*" wildcard,
+// is read from its last segment, see SegmentMatrix for the others.
+func matrixValues(r *http.Request, key, name string) ([]string, error) {
+	value, escaped := rawPathParam(r, key)
+	last := strings.TrimSuffix(value, "/")
+	if i := strings.LastIndexByte(last, '/'); i >= 0 {
+		last = last[i+1:]
+	}
+	s, err := parseSegment(last, escaped)
+	if err != nil {
+		return nil, wrapParam(key, value, err)
+	}
+	values, ok := s.Params[name]
+	if !ok {
+		return nil, ErrInvalidParam
+	}
+	return values, nil
+}
+
+// matrixValue parses the first value of a matrix parameter, errors are
+// reported for the matrix parameter name
+func matrixValue[T any](r *http.Request, key, name string, parse func(string) (T, error)) (T, error) {
+	var zero T
+	values, err := matrixValues(r, key, name)
+	if err != nil {
+		return zero, err
+	}
+	v, err := parse(values[0])
+	if err != nil {
+		return zero, wrapParam(name, values[0], err)
+	}
+	return v, nil
+}
+
+func intParser[T Integer](bits int, opts []IntOption) func(string) (T, error) {
+	return func(value string) (T, error) {
+		v, err := parseIntOpts(value, bits, opts)
+		return T(v), err
+	}
+}
+
+func uintParser[T Integer](bits int, opts []IntOption) func(string) (T, error) {
+	return func(value string) (T, error) {
+		v, err := parseUintOpts(value, bits, opts)
+		return T(v), err
+	}
+}
+
+// MatrixStringArray returns all values of a matrix parameter of a path parameter
+func MatrixStringArray(r *http.Request, key, name string) ([]string, error) {
+	return matrixValues(r, key, name)
+}
+
+// MatrixString returns a matrix parameter of a path parameter as a string type
+func MatrixString(r *http.Request, key, name string) (string, error) {
+	return matrixValue(r, key, name, parseString)
+}
+
+// MatrixInt returns a matrix parameter of a path parameter as an int type
+func MatrixInt(r *http.Request, key, name string, opts ...IntOption) (int, error) {
+	return matrixValue(r, key, name, func(value string) (int, error) {
+		return atoiOpts(value, opts)
+	})
+}
+
+// MatrixInt8 returns a matrix parameter of a path parameter as an int8 type
+func MatrixInt8(r *http.Request, key, name string, opts ...IntOption) (int8, error) {
+	return matrixValue(r, key, name, intParser[int8](8, opts))
+}
+
+// MatrixInt16 returns a matrix parameter of a path parameter as an int16 type
+func MatrixInt16(r *http.Request, key, name string, opts ...IntOption) (int16, error) {
+	return matrixValue(r, key, name, intParser[int16](16, opts))
+}
+
+// MatrixInt32 returns a matrix parameter of a path parameter as an int32 type
+func MatrixInt32(r *http.Request, key, name string, opts ...IntOption) (int32, error) {
+	return matrixValue(r, key, name, intParser[int32](32, opts))
+}
+
+// MatrixInt64 returns a matrix parameter of a path parameter as an int64 type
+func MatrixInt64(r *http.Request, key, name string, opts ...IntOption) (int64, error) {
+	return matrixValue(r, key, name, intParser[int64](64, opts))
+}
+
+// MatrixUint returns a matrix parameter of a path parameter as an uint type
+func MatrixUint(r *http.Request, key, name string, opts ...IntOption) (uint, error) {
+	return matrixValue(r, key, name, uintParser[uint](32, opts))
+}
+
+// MatrixUint8 returns a matrix parameter of a path parameter as an uint8 type
+func MatrixUint8(r *http.Request, key, name string, opts ...IntOption) (uint8, error) {
+	return matrixValue(r, key, name, uintParser[uint8](8, opts))
+}
+
+// MatrixUint16 returns a matrix parameter of a path parameter as an uint16 type
+func MatrixUint16(r *http.Request, key, name string, opts ...IntOption) (uint16, error) {
+	return matrixValue(r, key, name, uintParser[uint16](16, opts))
+}
+
+// MatrixUint32 returns a matrix parameter of a path parameter as an uint32 type
+func MatrixUint32(r *http.Request, key, name string, opts ...IntOption) (uint32, error) {
+	return matrixValue(r, key, name, uintParser[uint32](32, opts))
+}
+
+// MatrixUint64 returns a matrix parameter of a path parameter as an uint64 type
+func MatrixUint64(r *http.Request, key, name string, opts ...IntOption) (uint64, error) {
+	return matrixValue(r, key, name, uintParser[uint64](64, opts))
+}
+
+// MatrixBool returns a matrix parameter of a path parameter as a boolean type
+func MatrixBool(r *http.Request, key, name string) (bool, error) {
+	return matrixValue(r, key, name, strconv.ParseBool)
+}
+
+// MatrixFloat32 returns a matrix parameter of a path parameter as a float32 type
+func MatrixFloat32(r *http.Request, key, name string) (float32, error) {
+	return matrixValue(r, key, name, parseFloat32)
+}
+
+// MatrixFloat64 returns a matrix parameter of a path parameter as a float64 type
+func MatrixFloat64(r *http.Request, key, name string) (float64, error) {
+	return matrixValue(r, key, name, parseFloat64)
+}
+
+// MatrixTime returns a matrix parameter of a path parameter as a time type, formatted as RFC 3339
+func MatrixTime(r *http.Request, key, name string, opts ...TimeOption) (time.Time, error) {
+	return matrixValue(r, key, name, timeParser(opts))
+}
@@ -0,0 +1,161 @@
+package param
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseSegment(t *testing.T) {
+	got, err := ParseSegment("my%20item;color=red;color=blue;size=L;new;note=a%3Bb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Name != "my item" {
+		t.Fatalf("unexpected name %q", got.Name)
+	}
+
+	if !reflect.DeepEqual(got.Params["color"], []string{"red", "blue"}) || got.Params.Get("size") != "L" {
+		t.Fatalf("unexpected params %v", got.Params)
+	}
+
+	if _, ok := got.Params["new"]; !ok || got.Params.Get("note") != "a;b" {
+		t.Fatalf("unexpected params %v", got.Params)
+	}
+
+	if _, err := ParseSegment("a;b=%zz"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func wildcardSegments(r *http.Request) ([]Segment, error) {
+	return SegmentMatrix(r, "*")
+}
+
+func TestSegmentMatrix(t *testing.T) {
+	got, err := serveRequest(t, "/shop/*", "/shop/a;color=red;size=L/b/", wildcardSegments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || got[0].Name != "a" || got[0].Params.Get("color") != "red" || got[1].Name != "b" || len(got[1].Params) != 0 {
+		t.Fatalf("unexpected segments %+v", got)
+	}
+
+	// an escaped slash stays inside its segment
+	got, err = serveRequest(t, "/shop/*", "/shop/a%2Fb;note=x%3By", wildcardSegments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 1 || got[0].Name != "a/b" || got[0].Params.Get("note") != "x;y" {
+		t.Fatalf("unexpected segments %+v", got)
+	}
+
+	if got, err = serveRequest(t, "/shop/*", "/shop/", wildcardSegments); err != nil || len(got) != 0 {
+		t.Fatalf("unexpected segments %+v, %v", got, err)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	req, key := newParamRequest(t, "2022;tz=utc/05/31")
+
+	segments, err := Segments(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ParseSegments(segments, strconv.Atoi)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, []int{2022, 5, 31}) {
+		t.Fatalf("unexpected segments %v", got)
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	req, key := newParamRequest(t, "item;size=42;ratio=0.5;gift;tag=a;tag=b;at=2022-05-31T10:00:00Z")
+
+	size, err := MatrixInt(req, key, "size")
+	if err != nil || size != 42 {
+		t.Fatalf("unexpected size %d, %v", size, err)
+	}
+
+	small, err := MatrixUint8(req, key, "size")
+	if err != nil || small != 42 {
+		t.Fatalf("unexpected size %d, %v", small, err)
+	}
+
+	ratio, err := MatrixFloat64(req, key, "ratio")
+	if err != nil || ratio != 0.5 {
+		t.Fatalf("unexpected ratio %v, %v", ratio, err)
+	}
+
+	tags, err := MatrixStringArray(req, key, "tag")
+	if err != nil || !reflect.DeepEqual(tags, []string{"a", "b"}) {
+		t.Fatalf("unexpected tags %v, %v", tags, err)
+	}
+
+	at, err := MatrixTime(req, key, "at")
+	if err != nil || at.Day() != 31 {
+		t.Fatalf("unexpected time %v, %v", at, err)
+	}
+
+	if gift, err := MatrixString(req, key, "gift"); err != nil || gift != "" {
+		t.Fatalf("unexpected gift %q, %v", gift, err)
+	}
+}
+
+func TestMatrixErr(t *testing.T) {
+	req, key := newParamRequest(t, "item;size=L")
+
+	if _, err := MatrixInt(req, key, "color"); err != ErrInvalidParam {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+
+	_, err := MatrixInt(req, key, "size")
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Key != "size" || perr.Value != "L" {
+		t.Fatalf("want *Error, got %v", err)
+	}
+
+	if _, err := MatrixBool(req, key, "size"); !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestMatrixWildcard(t *testing.T) {
+	wildcardSize := func(r *http.Request) (int, error) {
+		return MatrixInt(r, "*", "size")
+	}
+
+	got, err := serveRequest(t, "/shop/*", "/shop/a;size=1/b;size=2/", wildcardSize)
+	if err != nil || got != 2 {
+		t.Fatalf("want the last segment's size 2, got %d, %v", got, err)
+	}
+
+	if _, err := serveRequest(t, "/shop/*", "/shop/a;size=1/b", wildcardSize); err != ErrInvalidParam {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestMatrixOptions(t *testing.T) {
+	req, key := newParamRequest(t, "item;size=0x2a;since=now-1h")
+
+	size, err := MatrixInt(req, key, "size", BasePrefix())
+	if err != nil || size != 42 {
+		t.Fatalf("unexpected size %d, %v", size, err)
+	}
+
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	since, err := MatrixTime(req, key, "since", RelativeTime(func() time.Time { return now }))
+	if err != nil || !since.Equal(now.Add(-time.Hour)) {
+		t.Fatalf("unexpected since %v, %v", since, err)
+	}
+}
@@ -0,0 +1,200 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ErrUnknownCurrency is an error for a currency code missing from ISO 4217
+var ErrUnknownCurrency = errors.New("Unknown currency")
+
+// Currency is an ISO 4217 currency
+type Currency struct {
+	Code       string
+	Number     int
+	MinorUnits int
+}
+
+// LookupCurrency returns the ISO 4217 currency for a code such as "EUR",
+// the code is case insensitive
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// Money is an amount in the minor unit of its currency, 1250 EUR cents
+// for 12.50 EUR
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+// Decimal returns the amount in the major unit of the currency
+func (m Money) Decimal() Decimal {
+	return Decimal{Unscaled: big.NewInt(m.Amount), Scale: m.Currency.MinorUnits}
+}
+
+// String returns the money in "12.50 EUR" form
+func (m Money) String() string {
+	return m.Decimal().String() + " " + m.Currency.Code
+}
+
+// MoneyOption configures how money parameters are parsed
+type MoneyOption func(*moneyOptions)
+
+type moneyOptions struct {
+	currencyKey string
+	allowed     map[string]bool
+}
+
+// CurrencyKey reads the currency from a separate parameter, such as
+// "currency" next to "amount", for amounts given without one. It is read
+// from the same source as the amount, the path or the query.
+func CurrencyKey(key string) MoneyOption {
+	return func(o *moneyOptions) {
+		o.currencyKey = key
+	}
+}
+
+// Currencies accepts only the given currency codes
+func Currencies(codes ...string) MoneyOption {
+	return func(o *moneyOptions) {
+		o.allowed = make(map[string]bool, len(codes))
+		for _, code := range codes {
+			o.allowed[strings.ToUpper(code)] = true
+		}
+	}
+}
+
+// ParseMoney parses an amount with its currency, such as "12.50 EUR",
+// "EUR12.50" or "EUR 12.50". The amount may not have more places than the
+// minor units of the currency, so "1.234 JPY" is rejected with ErrInexact.
+func ParseMoney(value string) (Money, error) {
+	return parseMoney(value, "")
+}
+
+// parseMoney parses value, using currency when value has no currency code
+func parseMoney(value, currency string) (Money, error) {
+	amount, code := splitCurrency(value)
+	if len(code) == 0 {
+		code = currency
+	} else if len(currency) > 0 && !strings.EqualFold(code, currency) {
+		return Money{}, fmt.Errorf("currency %s does not match %s", code, currency)
+	}
+	if len(code) == 0 {
+		return Money{}, errors.New("missing currency")
+	}
+
+	c, ok := LookupCurrency(code)
+	if !ok {
+		return Money{}, fmt.Errorf("%w %q", ErrUnknownCurrency, code)
+	}
+	d, err := ParseDecimal(amount, Scale(c.MinorUnits))
+	if err != nil {
+		return Money{}, err
+	}
+	if !d.Unscaled.IsInt64() {
+		return Money{}, numError("ParseMoney", value, strconv.ErrRange)
+	}
+	return Money{Amount: d.Unscaled.Int64(), Currency: c}, nil
+}
+
+// splitCurrency splits a three letter currency code before or after the
+// amount from it, the code is empty if there is none
+func splitCurrency(value string) (string, string) {
+	if len(value) > 3 && isLetters(value[:3]) {
+		return strings.TrimPrefix(value[3:], " "), value[:3]
+	}
+	if len(value) > 3 && isLetters(value[len(value)-3:]) {
+		return strings.TrimSuffix(value[:len(value)-3], " "), value[len(value)-3:]
+	}
+	return value, ""
+}
+
+func isLetters(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if c := value[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// moneyParser returns the parse function for the given options, currency
+// is the value of the currency key, if any
+func moneyParser(key, currency string, opts []MoneyOption) func(string) (Money, error) {
+	var o moneyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func(value string) (Money, error) {
+		m, err := parseMoney(value, currency)
+		if err != nil {
+			return Money{}, wrapParam(key, value, err)
+		}
+		if o.allowed != nil && !o.allowed[m.Currency.Code] {
+			return Money{}, invalidParam(key, value, "currency "+m.Currency.Code+" is not accepted")
+		}
+		return m, nil
+	}
+}
+
+func currencyKey(opts []MoneyOption) string {
+	var o moneyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.currencyKey
+}
+
+// PathMoney returns a path parameter as money, see ParseMoney
+func PathMoney(r *http.Request, key string, opts ...MoneyOption) (Money, error) {
+	var currency string
+	if ck := currencyKey(opts); len(ck) > 0 {
+		currency = chi.URLParam(r, ck)
+	}
+	return moneyParser(key, currency, opts)(chi.URLParam(r, key))
+}
+
+// QueryMoneyArray returns a slice of query parameters as money, a currency
+// key applies to all of them
+func QueryMoneyArray(r *http.Request, key string, opts ...MoneyOption) ([]Money, error) {
+	values, err := QueryStringArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+	var currency string
+	if ck := currencyKey(opts); len(ck) > 0 {
+		currency, _ = queryValue(r, ck)
+	}
+	parse := moneyParser(key, currency, opts)
+	out := make([]Money, len(values))
+	for index, value := range values {
+		v, err := parse(value)
+		if err != nil {
+			return nil, err
+		}
+		out[index] = v
+	}
+	return out, nil
+}
+
+// QueryMoney returns a query parameter as money, see ParseMoney
+func QueryMoney(r *http.Request, key string, opts ...MoneyOption) (Money, error) {
+	values, err := QueryMoneyArray(r, key, opts...)
+	if err != nil {
+		return Money{}, err
+	}
+	return values[0], nil
+}
+
+// Money sets a query parameter with money type
+func (q *Query) Money(key string, value Money) *Query {
+	return q.set(key, []string{value.String()})
+}
@@ -0,0 +1,117 @@
+package param
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value  string
+		amount int64
+		code   string
+	}{
+		{"12.50 EUR", 1250, "EUR"},
+		{"EUR12.5", 1250, "EUR"},
+		{"usd 3", 300, "USD"},
+		{"-0.01USD", -1, "USD"},
+		{"1000 JPY", 1000, "JPY"},
+		{"1.234 KWD", 1234, "KWD"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if got.Amount != tt.amount || got.Currency.Code != tt.code {
+			t.Fatalf("%s: want %d %s, got %d %s", tt.value, tt.amount, tt.code, got.Amount, got.Currency.Code)
+		}
+	}
+}
+
+func TestParseMoneyErr(t *testing.T) {
+	_, err := ParseMoney("1.234 JPY")
+	if !errors.Is(err, ErrInexact) {
+		t.Fatalf("want ErrInexact, got %v", err)
+	}
+
+	_, err = ParseMoney("12.50 ABC")
+	if !errors.Is(err, ErrUnknownCurrency) {
+		t.Fatalf("want ErrUnknownCurrency, got %v", err)
+	}
+
+	for _, value := range []string{"12.50", "EUR", "12,50 EUR", "EUR 12.50 EUR", "99999999999999999999 EUR"} {
+		if _, err := ParseMoney(value); err == nil {
+			t.Fatalf("%q: expected error", value)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	m := Money{Amount: -5, Currency: currencies["EUR"]}
+	if m.String() != "-0.05 EUR" {
+		t.Fatalf("want -0.05 EUR, got %s", m)
+	}
+
+	m = Money{Amount: 500, Currency: currencies["JPY"]}
+	if m.String() != "500 JPY" {
+		t.Fatalf("want 500 JPY, got %s", m)
+	}
+}
+
+func TestQueryMoney(t *testing.T) {
+	q := NewQuery().Money("amount", Money{Amount: 1250, Currency: currencies["EUR"]})
+	req := newQueryRequest(t, q.Encode())
+
+	got, err := QueryMoney(req, "amount")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Amount != 1250 || got.Currency.Code != "EUR" {
+		t.Fatalf("want 12.50 EUR, got %s", got)
+	}
+
+	_, err = QueryMoney(req, "amount", Currencies("USD"))
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestQueryMoneyCurrencyKey(t *testing.T) {
+	req := newQueryRequest(t, "amount=10&amount=2.5&currency=GBP")
+
+	got, err := QueryMoneyArray(req, "amount", CurrencyKey("currency"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || got[0].Amount != 1000 || got[1].Amount != 250 || got[1].Currency.Code != "GBP" {
+		t.Fatalf("unexpected values %v", got)
+	}
+
+	_, err = QueryMoney(newQueryRequest(t, "amount=10+EUR&currency=GBP"), "amount", CurrencyKey("currency"))
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+
+	_, err = QueryMoney(newQueryRequest(t, "amount=10"), "amount", CurrencyKey("currency"))
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestPathMoney(t *testing.T) {
+	req, key := newParamRequest(t, "JPY1500")
+
+	got, err := PathMoney(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Amount != 1500 || got.Currency.MinorUnits != 0 {
+		t.Fatalf("want 1500 JPY, got %s", got)
+	}
+}
@@ -0,0 +1,276 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+)
+
+// ErrForbiddenAddress is an error for an address or prefix that is valid
+// but not allowed by policy
+var ErrForbiddenAddress = errors.New("Address is not allowed")
+
+// NetOption configures which addresses and prefixes are accepted
+type NetOption func(*netOptions)
+
+type netOptions struct {
+	v4Only   bool
+	v6Only   bool
+	denied   []netRange
+	within   []netip.Prefix
+	noZone   bool
+	noUnspec bool
+}
+
+type netRange struct {
+	name     string
+	prefixes []netip.Prefix
+}
+
+func mustPrefixes(values ...string) []netip.Prefix {
+	out := make([]netip.Prefix, len(values))
+	for index, value := range values {
+		out[index] = netip.MustParsePrefix(value)
+	}
+	return out
+}
+
+var (
+	privateRange   = netRange{"private", mustPrefixes("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7")}
+	loopbackRange  = netRange{"loopback", mustPrefixes("127.0.0.0/8", "::1/128")}
+	linkLocalRange = netRange{"link-local", mustPrefixes("169.254.0.0/16", "fe80::/10", "224.0.0.0/24", "ff02::/16")}
+	multicastRange = netRange{"multicast", mustPrefixes("224.0.0.0/4", "ff00::/8")}
+	// other ranges that never reach the public internet
+	reservedRange = netRange{"reserved", mustPrefixes("0.0.0.0/8", "192.0.0.0/24", "192.0.2.0/24", "198.18.0.0/15",
+		"198.51.100.0/24", "203.0.113.0/24", "240.0.0.0/4", "::/128", "64:ff9b:1::/48", "100::/64", "2001:db8::/32")}
+)
+
+// embeddingRanges are the IPv6 ranges with an IPv4 address in their last
+// 32 bits, IPv4 mapped addresses and the well-known NAT64 prefix. The
+// local-use NAT64 prefix 64:ff9b:1::/48 embeds the address where the
+// network chooses to, so it is only rejected as reserved.
+var embeddingRanges = mustPrefixes("::ffff:0:0/96", "64:ff9b::/96")
+
+// embeddedIPv4 returns the IPv4 prefix of the addresses p embeds when it
+// overlaps the /96 embedding range, a shorter prefix spans every IPv4 address
+func embeddedIPv4(p, embedding netip.Prefix) (netip.Prefix, bool) {
+	if !p.Overlaps(embedding) {
+		return netip.Prefix{}, false
+	}
+	if p.Bits() < 96 {
+		return netip.PrefixFrom(netip.IPv4Unspecified(), 0), true
+	}
+	b := p.Addr().As16()
+	return netip.PrefixFrom(netip.AddrFrom4([4]byte{b[12], b[13], b[14], b[15]}), p.Bits()-96), true
+}
+
+// IPv4Only accepts only IPv4 addresses, including IPv4 mapped IPv6 ones
+func IPv4Only() NetOption {
+	return func(o *netOptions) {
+		o.v4Only = true
+	}
+}
+
+// IPv6Only accepts only IPv6 addresses that are not IPv4 mapped
+func IPv6Only() NetOption {
+	return func(o *netOptions) {
+		o.v6Only = true
+	}
+}
+
+// NoPrivate rejects private addresses, RFC 1918, RFC 6598 shared space
+// and IPv6 unique local addresses
+func NoPrivate() NetOption {
+	return func(o *netOptions) {
+		o.denied = append(o.denied, privateRange)
+	}
+}
+
+// NoLoopback rejects loopback addresses
+func NoLoopback() NetOption {
+	return func(o *netOptions) {
+		o.denied = append(o.denied, loopbackRange)
+	}
+}
+
+// NoLinkLocal rejects link-local unicast and multicast addresses
+func NoLinkLocal() NetOption {
+	return func(o *netOptions) {
+		o.denied = append(o.denied, linkLocalRange)
+	}
+}
+
+// PublicOnly rejects every address that is not public unicast: private,
+// loopback, link-local, multicast, unspecified, documentation and other
+// reserved addresses, and addresses with a zone. IPv4 mapped and NAT64
+// addresses are judged by the IPv4 address they embed.
+func PublicOnly() NetOption {
+	return func(o *netOptions) {
+		o.denied = append(o.denied, privateRange, loopbackRange, linkLocalRange, multicastRange, reservedRange)
+		o.noZone, o.noUnspec = true, true
+	}
+}
+
+// Within accepts only addresses and prefixes inside one of supernets
+func Within(supernets ...netip.Prefix) NetOption {
+	return func(o *netOptions) {
+		o.within = append(o.within, supernets...)
+	}
+}
+
+func netPolicy(opts []NetOption) netOptions {
+	var o netOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// check applies the policy to a prefix, an address is checked as a prefix
+// of its full length
+func (o netOptions) check(p netip.Prefix, zone string) error {
+	addr := p.Addr()
+	switch {
+	case o.v4Only && !addr.Unmap().Is4():
+		return fmt.Errorf("%w: %s is not IPv4", ErrForbiddenAddress, p)
+	case o.v6Only && (addr.Is4() || addr.Is4In6()):
+		return fmt.Errorf("%w: %s is not IPv6", ErrForbiddenAddress, p)
+	case o.noZone && len(zone) > 0:
+		return fmt.Errorf("%w: %s has a zone", ErrForbiddenAddress, p)
+	case o.noUnspec && addr.Unmap().IsUnspecified():
+		return fmt.Errorf("%w: %s is unspecified", ErrForbiddenAddress, p)
+	}
+
+	// IPv4 mapped and NAT64 addresses are also checked against the IPv4
+	// ranges of the addresses they reach
+	checked := []netip.Prefix{p}
+	for _, e := range embeddingRanges {
+		if v4, ok := embeddedIPv4(p, e); ok {
+			checked = append(checked, v4)
+		}
+	}
+	for _, r := range o.denied {
+		for _, d := range r.prefixes {
+			for _, c := range checked {
+				if d.Overlaps(c) {
+					return fmt.Errorf("%w: %s is %s", ErrForbiddenAddress, p, r.name)
+				}
+			}
+		}
+	}
+
+	if o.within == nil {
+		return nil
+	}
+	for _, s := range o.within {
+		if s.Bits() <= p.Bits() && s.Contains(addr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is outside the allowed networks", ErrForbiddenAddress, p)
+}
+
+// ParseAddr parses an IPv4 or IPv6 address and applies the options
+func ParseAddr(value string, opts ...NetOption) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	bare := addr.WithZone("")
+	if err := netPolicy(opts).check(netip.PrefixFrom(bare, bare.BitLen()), addr.Zone()); err != nil {
+		return netip.Addr{}, err
+	}
+	return addr, nil
+}
+
+// ParsePrefix parses a CIDR prefix such as "10.0.0.0/8" and applies the
+// options. A prefix is rejected if any of its addresses would be.
+func ParsePrefix(value string, opts ...NetOption) (netip.Prefix, error) {
+	p, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if err := netPolicy(opts).check(p.Masked(), ""); err != nil {
+		return netip.Prefix{}, err
+	}
+	return p, nil
+}
+
+func addrParser(opts []NetOption) func(string) (netip.Addr, error) {
+	return func(value string) (netip.Addr, error) {
+		return ParseAddr(value, opts...)
+	}
+}
+
+func prefixParser(opts []NetOption) func(string) (netip.Prefix, error) {
+	return func(value string) (netip.Prefix, error) {
+		return ParsePrefix(value, opts...)
+	}
+}
+
+// PathAddr returns a path parameter as an IP address
+func PathAddr(r *http.Request, key string, opts ...NetOption) (netip.Addr, error) {
+	return pathID(r, key, addrParser(opts))
+}
+
+// PathPrefix returns a path parameter as a CIDR prefix, the "/" has to be
+// escaped as %2F or the route has to use a wildcard
+func PathPrefix(r *http.Request, key string, opts ...NetOption) (netip.Prefix, error) {
+	return pathID(r, key, func(value string) (netip.Prefix, error) {
+		unescaped, err := url.PathUnescape(value)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return ParsePrefix(unescaped, opts...)
+	})
+}
+
+// PathMAC returns a path parameter as a hardware address, see net.ParseMAC
+func PathMAC(r *http.Request, key string) (net.HardwareAddr, error) {
+	return pathID(r, key, net.ParseMAC)
+}
+
+// QueryAddrArray returns a slice of query parameters with IP address type
+func QueryAddrArray(r *http.Request, key string, opts ...NetOption) ([]netip.Addr, error) {
+	return queryIDArray(r, key, addrParser(opts))
+}
+
+// QueryPrefixArray returns a slice of query parameters with CIDR prefix type
+func QueryPrefixArray(r *http.Request, key string, opts ...NetOption) ([]netip.Prefix, error) {
+	return queryIDArray(r, key, prefixParser(opts))
+}
+
+// QueryMACArray returns a slice of query parameters with hardware address type
+func QueryMACArray(r *http.Request, key string) ([]net.HardwareAddr, error) {
+	return queryIDArray(r, key, net.ParseMAC)
+}
+
+// QueryAddr returns a query parameter with IP address type
+func QueryAddr(r *http.Request, key string, opts ...NetOption) (netip.Addr, error) {
+	values, err := QueryAddrArray(r, key, opts...)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return values[0], nil
+}
+
+// QueryPrefix returns a query parameter with CIDR prefix type
+func QueryPrefix(r *http.Request, key string, opts ...NetOption) (netip.Prefix, error) {
+	values, err := QueryPrefixArray(r, key, opts...)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return values[0], nil
+}
+
+// QueryMAC returns a query parameter with hardware address type
+func QueryMAC(r *http.Request, key string) (net.HardwareAddr, error) {
+	values, err := QueryMACArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
@@ -0,0 +1,137 @@
+package param
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestParseAddr(t *testing.T) {
+	tests := []struct {
+		value string
+		opts  []NetOption
+		ok    bool
+	}{
+		{"8.8.8.8", []NetOption{PublicOnly()}, true},
+		{"2606:4700::1111", []NetOption{PublicOnly(), IPv6Only()}, true},
+		{"10.1.2.3", nil, true},
+		{"10.1.2.3", []NetOption{NoPrivate()}, false},
+		{"::ffff:10.1.2.3", []NetOption{NoPrivate()}, false},
+		{"fd00::1", []NetOption{NoPrivate()}, false},
+		{"127.0.0.1", []NetOption{NoPrivate()}, true},
+		{"127.0.0.1", []NetOption{NoLoopback()}, false},
+		{"::1", []NetOption{NoLoopback()}, false},
+		{"169.254.169.254", []NetOption{NoLinkLocal()}, false},
+		{"fe80::1%eth0", []NetOption{NoLinkLocal()}, false},
+		{"0.0.0.0", []NetOption{PublicOnly()}, false},
+		{"224.0.0.251", []NetOption{PublicOnly()}, false},
+		{"192.0.2.1", []NetOption{PublicOnly()}, false},
+		{"::ffff:1.2.3.4", []NetOption{IPv4Only()}, true},
+		{"::ffff:1.2.3.4", []NetOption{IPv6Only()}, false},
+		{"2001:db8::1", []NetOption{IPv4Only()}, false},
+		{"64:ff9b::7f00:1", []NetOption{PublicOnly()}, false},
+		{"64:ff9b::a01:203", []NetOption{NoPrivate()}, false},
+		{"64:ff9b::808:808", []NetOption{PublicOnly()}, true},
+		{"64:ff9b:1::7f00:1", []NetOption{PublicOnly()}, false},
+		{"::ffff:127.0.0.1", []NetOption{PublicOnly()}, false},
+		{"10.1.2.3", []NetOption{Within(netip.MustParsePrefix("10.0.0.0/8"))}, true},
+		{"11.1.2.3", []NetOption{Within(netip.MustParsePrefix("10.0.0.0/8"))}, false},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseAddr(tt.value, tt.opts...)
+		if tt.ok && err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if !tt.ok && !errors.Is(err, ErrForbiddenAddress) {
+			t.Fatalf("%s: want ErrForbiddenAddress, got %v", tt.value, err)
+		}
+	}
+
+	if _, err := ParseAddr("300.1.2.3"); err == nil {
+		t.Fatal("expected a syntax error")
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	supernet := Within(netip.MustParsePrefix("10.0.0.0/8"))
+
+	got, err := ParsePrefix("10.20.0.0/16", supernet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Bits() != 16 {
+		t.Fatalf("unexpected prefix %s", got)
+	}
+
+	for _, tt := range []struct {
+		value string
+		opt   NetOption
+	}{
+		{"10.0.0.0/7", supernet},
+		{"11.0.0.0/16", supernet},
+		{"0.0.0.0/0", NoPrivate()},
+		{"172.0.0.0/8", NoPrivate()},
+		{"2001:db8::/48", IPv4Only()},
+		// spans ::ffff:0:0/96 and so every mapped IPv4 address
+		{"::fffe:0:0/95", PublicOnly()},
+		{"::ffff:127.0.0.0/104", NoLoopback()},
+		{"64:ff9b::/96", NoPrivate()},
+	} {
+		if _, err := ParsePrefix(tt.value, tt.opt); !errors.Is(err, ErrForbiddenAddress) {
+			t.Fatalf("%s: want ErrForbiddenAddress, got %v", tt.value, err)
+		}
+	}
+}
+
+func TestPathPrefix(t *testing.T) {
+	req, key := newParamRequest(t, "192.168.0.0%2F24")
+
+	got, err := PathPrefix(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != netip.MustParsePrefix("192.168.0.0/24") {
+		t.Fatalf("unexpected prefix %s", got)
+	}
+
+	_, err = PathPrefix(req, key, PublicOnly())
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("want ErrForbiddenAddress, got %v", err)
+	}
+}
+
+func TestQueryAddrArray(t *testing.T) {
+	req := newQueryRequest(t, "ip=1.1.1.1&ip=2606:4700::1111&mac=00:00:5e:00:53:01")
+
+	got, err := QueryAddrArray(req, "ip", PublicOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || !got[0].Is4() || !got[1].Is6() {
+		t.Fatalf("unexpected addresses %v", got)
+	}
+
+	_, err = QueryAddr(req, "ip", IPv6Only())
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+
+	mac, err := QueryMAC(req, "mac")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mac.String() != "00:00:5e:00:53:01" {
+		t.Fatalf("unexpected MAC %s", mac)
+	}
+
+	_, err = QueryMAC(req, "ip")
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
@@ -0,0 +1,329 @@
+package param
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Null is a parameter value that can be absent, explicitly null or set.
+// Set reports whether the key was present in the request and Valid reports
+// whether it carried a value other than an empty string or "null".
+type Null[T any] struct {
+	V     T
+	Valid bool
+	Set   bool
+}
+
+// Ptr returns a pointer to the value or nil if the value is not valid
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.V
+	return &v
+}
+
+// Value implements the driver.Valuer interface, so a Null can be passed
+// to database/sql queries the same way as sql.NullInt64 and friends
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(n.V)
+}
+
+// Scan implements the sql.Scanner interface, so a Null can be read back
+// from a database. A NULL column is Set but not Valid. Columns are
+// converted with the same rules as the getters of T, numbers must fit T.
+func (n *Null[T]) Scan(src interface{}) error {
+	if src == nil {
+		*n = Null[T]{Set: true}
+		return nil
+	}
+	if v, ok := src.(T); ok {
+		*n = Null[T]{V: v, Valid: true, Set: true}
+		return nil
+	}
+
+	var v T
+	if err := scanValue(reflect.ValueOf(&v).Elem(), src); err != nil {
+		return err
+	}
+	*n = Null[T]{V: v, Valid: true, Set: true}
+	return nil
+}
+
+// scanValue stores a database value in dst
+func scanValue(dst reflect.Value, src interface{}) error {
+	unsupported := fmt.Errorf("%w: can not scan %T into %s", ErrUnsupportedType, src, dst.Type())
+	switch s := src.(type) {
+	case []byte:
+		return scanString(dst, string(s), unsupported)
+	case string:
+		return scanString(dst, s, unsupported)
+	case int64:
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if dst.OverflowInt(s) {
+				return numError("Scan", strconv.FormatInt(s, 10), strconv.ErrRange)
+			}
+			dst.SetInt(s)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if s < 0 || dst.OverflowUint(uint64(s)) {
+				return numError("Scan", strconv.FormatInt(s, 10), strconv.ErrRange)
+			}
+			dst.SetUint(uint64(s))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(s))
+			return nil
+		}
+	case float64:
+		if dst.Kind() == reflect.Float32 || dst.Kind() == reflect.Float64 {
+			dst.SetFloat(s)
+			return nil
+		}
+	case bool:
+		if dst.Kind() == reflect.Bool {
+			dst.SetBool(s)
+			return nil
+		}
+	}
+	return unsupported
+}
+
+// scanString parses a textual database value into dst
+func scanString(dst reflect.Value, s string, unsupported error) error {
+	if dst.Type() == timeType {
+		t, err := parseTime(s)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(s, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(v)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(v)
+	default:
+		return unsupported
+	}
+	return nil
+}
+
+// nullable is implemented by Null so it can be encoded without knowing its type argument
+type nullable interface {
+	nullState() (value interface{}, valid bool, set bool)
+}
+
+func (n Null[T]) nullState() (interface{}, bool, bool) {
+	return n.V, n.Valid, n.Set
+}
+
+// isNull reports whether a raw parameter value stands for an explicit null
+func isNull(value string) bool {
+	return len(value) == 0 || value == "null"
+}
+
+func newNull[T any](value string, set bool, parse func(string) (T, error)) (Null[T], error) {
+	if !set {
+		return Null[T]{}, nil
+	}
+	if isNull(value) {
+		return Null[T]{Set: true}, nil
+	}
+	v, err := parse(value)
+	if err != nil {
+		return Null[T]{Set: true}, err
+	}
+	return Null[T]{V: v, Valid: true, Set: true}, nil
+}
+
+func pathNull[T any](r *http.Request, key string, parse func(string) (T, error)) (Null[T], error) {
+	value, set := pathValue(r, key)
+	return newNull(value, set, parse)
+}
+
+func queryNull[T any](r *http.Request, key string, parse func(string) (T, error)) (Null[T], error) {
+	value, set := queryValue(r, key)
+	return newNull(value, set, parse)
+}
+
+// NullString returns a path parameter as a nullable string type
+func NullString(r *http.Request, key string) (Null[string], error) {
+	return pathNull(r, key, parseString)
+}
+
+// NullInt returns a path parameter as a nullable int type
+func NullInt(r *http.Request, key string) (Null[int], error) {
+	return pathNull(r, key, strconv.Atoi)
+}
+
+// NullInt8 returns a path parameter as a nullable int8 type
+func NullInt8(r *http.Request, key string) (Null[int8], error) {
+	return pathNull(r, key, parseInt8)
+}
+
+// NullInt16 returns a path parameter as a nullable int16 type
+func NullInt16(r *http.Request, key string) (Null[int16], error) {
+	return pathNull(r, key, parseInt16)
+}
+
+// NullInt32 returns a path parameter as a nullable int32 type
+func NullInt32(r *http.Request, key string) (Null[int32], error) {
+	return pathNull(r, key, parseInt32)
+}
+
+// NullInt64 returns a path parameter as a nullable int64 type
+func NullInt64(r *http.Request, key string) (Null[int64], error) {
+	return pathNull(r, key, parseInt64)
+}
+
+// NullUint returns a path parameter as a nullable uint type
+func NullUint(r *http.Request, key string) (Null[uint], error) {
+	return pathNull(r, key, parseUint)
+}
+
+// NullUint8 returns a path parameter as a nullable uint8 type
+func NullUint8(r *http.Request, key string) (Null[uint8], error) {
+	return pathNull(r, key, parseUint8)
+}
+
+// NullUint16 returns a path parameter as a nullable uint16 type
+func NullUint16(r *http.Request, key string) (Null[uint16], error) {
+	return pathNull(r, key, parseUint16)
+}
+
+// NullUint32 returns a path parameter as a nullable uint32 type
+func NullUint32(r *http.Request, key string) (Null[uint32], error) {
+	return pathNull(r, key, parseUint32)
+}
+
+// NullUint64 returns a path parameter as a nullable uint64 type
+func NullUint64(r *http.Request, key string) (Null[uint64], error) {
+	return pathNull(r, key, parseUint64)
+}
+
+// NullBool returns a path parameter as a nullable boolean type
+func NullBool(r *http.Request, key string) (Null[bool], error) {
+	return pathNull(r, key, strconv.ParseBool)
+}
+
+// NullFloat32 returns a path parameter as a nullable float32 type
+func NullFloat32(r *http.Request, key string) (Null[float32], error) {
+	return pathNull(r, key, parseFloat32)
+}
+
+// NullFloat64 returns a path parameter as a nullable float64 type
+func NullFloat64(r *http.Request, key string) (Null[float64], error) {
+	return pathNull(r, key, parseFloat64)
+}
+
+// NullTime returns a path parameter as a nullable time type
+func NullTime(r *http.Request, key string) (Null[time.Time], error) {
+	return pathNull(r, key, parseTime)
+}
+
+// QueryNullString returns a query parameter with nullable string type
+func QueryNullString(r *http.Request, key string) (Null[string], error) {
+	return queryNull(r, key, parseString)
+}
+
+// QueryNullInt returns a query parameter with nullable int type
+func QueryNullInt(r *http.Request, key string) (Null[int], error) {
+	return queryNull(r, key, strconv.Atoi)
+}
+
+// QueryNullInt8 returns a query parameter with nullable int8 type
+func QueryNullInt8(r *http.Request, key string) (Null[int8], error) {
+	return queryNull(r, key, parseInt8)
+}
+
+// QueryNullInt16 returns a query parameter with nullable int16 type
+func QueryNullInt16(r *http.Request, key string) (Null[int16], error) {
+	return queryNull(r, key, parseInt16)
+}
+
+// QueryNullInt32 returns a query parameter with nullable int32 type
+func QueryNullInt32(r *http.Request, key string) (Null[int32], error) {
+	return queryNull(r, key, parseInt32)
+}
+
+// QueryNullInt64 returns a query parameter with nullable int64 type
+func QueryNullInt64(r *http.Request, key string) (Null[int64], error) {
+	return queryNull(r, key, parseInt64)
+}
+
+// QueryNullUint returns a query parameter with nullable uint type
+func QueryNullUint(r *http.Request, key string) (Null[uint], error) {
+	return queryNull(r, key, parseUint)
+}
+
+// QueryNullUint8 returns a query parameter with nullable uint8 type
+func QueryNullUint8(r *http.Request, key string) (Null[uint8], error) {
+	return queryNull(r, key, parseUint8)
+}
+
+// QueryNullUint16 returns a query parameter with nullable uint16 type
+func QueryNullUint16(r *http.Request, key string) (Null[uint16], error) {
+	return queryNull(r, key, parseUint16)
+}
+
+// QueryNullUint32 returns a query parameter with nullable uint32 type
+func QueryNullUint32(r *http.Request, key string) (Null[uint32], error) {
+	return queryNull(r, key, parseUint32)
+}
+
+// QueryNullUint64 returns a query parameter with nullable uint64 type
+func QueryNullUint64(r *http.Request, key string) (Null[uint64], error) {
+	return queryNull(r, key, parseUint64)
+}
+
+// QueryNullBool returns a query parameter with nullable boolean type
+func QueryNullBool(r *http.Request, key string) (Null[bool], error) {
+	return queryNull(r, key, strconv.ParseBool)
+}
+
+// QueryNullFloat32 returns a query parameter with nullable float32 type
+func QueryNullFloat32(r *http.Request, key string) (Null[float32], error) {
+	return queryNull(r, key, parseQueryFloat32)
+}
+
+// QueryNullFloat64 returns a query parameter with nullable float64 type
+func QueryNullFloat64(r *http.Request, key string) (Null[float64], error) {
+	return queryNull(r, key, parseQueryFloat64)
+}
+
+// QueryNullTime returns a query parameter with nullable time type
+func QueryNullTime(r *http.Request, key string) (Null[time.Time], error) {
+	return queryNull(r, key, parseQueryTime)
+}
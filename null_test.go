@@ -0,0 +1,176 @@
+package param
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNullInt64(t *testing.T) {
+	req, key := newParamRequest(t, "42")
+
+	got, err := NullInt64(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Null[int64]{V: 42, Valid: true, Set: true}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestNullInt64Null(t *testing.T) {
+	req, key := newParamRequest(t, "null")
+
+	got, err := NullInt64(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Set || got.Valid {
+		t.Fatalf("want explicit null, got %+v", got)
+	}
+
+	got, err = NullInt64(req, "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Set || got.Valid {
+		t.Fatalf("want absent value, got %+v", got)
+	}
+}
+
+func TestNullInt64Err(t *testing.T) {
+	req, key := newParamRequest(t, "ten")
+
+	got, err := NullInt64(req, key)
+	if err == nil {
+		t.Fatal("NullInt64('ten') should throw an error")
+	}
+
+	if !got.Set || got.Valid {
+		t.Fatalf("want set but invalid value, got %+v", got)
+	}
+}
+
+func TestQueryNullBool(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Null[bool]
+	}{
+		{"archived=true", Null[bool]{V: true, Valid: true, Set: true}},
+		{"archived=false", Null[bool]{V: false, Valid: true, Set: true}},
+		{"archived=null", Null[bool]{Set: true}},
+		{"archived=", Null[bool]{Set: true}},
+		{"other=true", Null[bool]{}},
+	}
+
+	for _, tt := range tests {
+		req := newQueryRequest(t, tt.query)
+
+		got, err := QueryNullBool(req, "archived")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("%s: got %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestQueryNullBoolErr(t *testing.T) {
+	req := newQueryRequest(t, "archived=truth")
+
+	_, err := QueryNullBool(req, "archived")
+	if err == nil {
+		t.Fatal("expected error parsing invalid bool")
+	}
+}
+
+func TestQueryNullFloat64(t *testing.T) {
+	req := newQueryRequest(t, "value=1e+3")
+
+	got, err := QueryNullFloat64(req, "value")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Valid || got.V != 1000 {
+		t.Fatalf("want 1000, got %+v", got)
+	}
+}
+
+func TestNullPtr(t *testing.T) {
+	if p := (Null[int]{Set: true}).Ptr(); p != nil {
+		t.Fatalf("want nil pointer, got %v", *p)
+	}
+
+	p := Null[int]{V: 7, Valid: true, Set: true}.Ptr()
+	if p == nil || *p != 7 {
+		t.Fatalf("want pointer to 7, got %v", p)
+	}
+}
+
+func TestNullValue(t *testing.T) {
+	var v driver.Valuer = Null[int8]{V: -3, Valid: true, Set: true}
+
+	got, err := v.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != int64(-3) {
+		t.Fatalf("want int64(-3), got %#v", got)
+	}
+
+	got, err = Null[string]{Set: true}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != nil {
+		t.Fatalf("want nil, got %#v", got)
+	}
+}
+
+func TestNullScan(t *testing.T) {
+	var n Null[int16]
+	var _ sql.Scanner = &n
+
+	if err := n.Scan(int64(-7)); err != nil || n != (Null[int16]{V: -7, Valid: true, Set: true}) {
+		t.Fatalf("unexpected scan %+v, %v", n, err)
+	}
+
+	if err := n.Scan([]byte("12")); err != nil || n.V != 12 {
+		t.Fatalf("unexpected scan %+v, %v", n, err)
+	}
+
+	if err := n.Scan(nil); err != nil || n != (Null[int16]{Set: true}) {
+		t.Fatalf("unexpected scan %+v, %v", n, err)
+	}
+
+	if err := n.Scan(int64(70000)); !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("want strconv.ErrRange, got %v", err)
+	}
+
+	if err := n.Scan(true); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("want ErrUnsupportedType, got %v", err)
+	}
+
+	var at Null[time.Time]
+	want := time.Date(2022, 5, 31, 10, 0, 0, 0, time.UTC)
+	if err := at.Scan(want); err != nil || !at.V.Equal(want) {
+		t.Fatalf("unexpected scan %+v, %v", at, err)
+	}
+
+	var s Null[string]
+	if err := s.Scan([]byte("open")); err != nil || s.V != "open" || !s.Valid {
+		t.Fatalf("unexpected scan %+v, %v", s, err)
+	}
+}
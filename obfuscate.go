@@ -0,0 +1,173 @@
+package param
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// DefaultAlphabet is an alphabet of letters and digits for NewObfuscator
+const DefaultAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
+
+// ErrInvalidAlphabet is an error for an obfuscator alphabet that is too
+// short or repeats characters
+var ErrInvalidAlphabet = errors.New("Alphabet needs at least 16 unique ASCII characters")
+
+// ErrNoObfuscator is an error for the package level helpers used before
+// DefaultObfuscator is set
+var ErrNoObfuscator = errors.New("DefaultObfuscator is not set")
+
+// Obfuscator turns int64 IDs into short strings and back, in the style of
+// hashids. The output depends on the alphabet and salt, which keeps
+// sequential IDs from showing in URLs. It is not encryption, the salt only
+// makes the mapping harder to guess.
+type Obfuscator struct {
+	alphabet string
+	salt     string
+}
+
+// DefaultObfuscator is used by ObfuscatedInt64 and the other package level
+// helpers. It is nil until set at startup with a salt of your own, so the
+// helpers fail with ErrNoObfuscator instead of using a well known mapping.
+var DefaultObfuscator *Obfuscator
+
+// NewObfuscator returns an obfuscator using the characters of alphabet,
+// shuffled by salt
+func NewObfuscator(alphabet, salt string) (*Obfuscator, error) {
+	if len(alphabet) < 16 {
+		return nil, ErrInvalidAlphabet
+	}
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c <= ' ' || c > '~' || strings.IndexByte(alphabet[i+1:], c) >= 0 {
+			return nil, ErrInvalidAlphabet
+		}
+	}
+	return &Obfuscator{alphabet: shuffle(alphabet, salt), salt: salt}, nil
+}
+
+// shuffle reorders alphabet in a way that only depends on salt
+func shuffle(alphabet, salt string) string {
+	out := []byte(alphabet)
+	if len(salt) == 0 {
+		return alphabet
+	}
+	for i, v, p := len(out)-1, 0, 0; i > 0; i-- {
+		v %= len(salt)
+		n := int(salt[v])
+		p += n
+		j := (n + v + p) % i
+		out[i], out[j] = out[j], out[i]
+		v++
+	}
+	return string(out)
+}
+
+// Encode returns the obfuscated form of id. Negative IDs are supported.
+func (o *Obfuscator) Encode(id int64) string {
+	// zigzag encoding folds negative IDs in between the positive ones
+	u := uint64(id<<1) ^ uint64(id>>63)
+
+	// a lottery character picked from the ID reshuffles the alphabet, so
+	// consecutive IDs do not share a common prefix
+	lottery := o.alphabet[u%100%uint64(len(o.alphabet))]
+	alphabet := shuffle(o.alphabet, (string(lottery) + o.salt + o.alphabet)[:len(o.alphabet)])
+
+	base := uint64(len(alphabet))
+	var digits []byte
+	for {
+		digits = append(digits, alphabet[u%base])
+		u /= base
+		if u == 0 {
+			break
+		}
+	}
+	out := make([]byte, 0, len(digits)+1)
+	out = append(out, lottery)
+	for i := len(digits) - 1; i >= 0; i-- {
+		out = append(out, digits[i])
+	}
+	return string(out)
+}
+
+// Decode returns the ID encoded in value. Values that Encode would not
+// produce, including other encodings of the same ID, are rejected with
+// ErrMalformedID.
+func (o *Obfuscator) Decode(value string) (int64, error) {
+	if len(value) < 2 || strings.IndexByte(o.alphabet, value[0]) < 0 {
+		return 0, malformed("obfuscated ID", value)
+	}
+	alphabet := shuffle(o.alphabet, (value[:1] + o.salt + o.alphabet)[:len(o.alphabet)])
+
+	base := uint64(len(alphabet))
+	var u uint64
+	for i := 1; i < len(value); i++ {
+		d := strings.IndexByte(alphabet, value[i])
+		if d < 0 || u > (^uint64(0)-uint64(d))/base {
+			return 0, malformed("obfuscated ID", value)
+		}
+		u = u*base + uint64(d)
+	}
+	id := int64(u>>1) ^ -int64(u&1)
+	if o.Encode(id) != value {
+		return 0, malformed("obfuscated ID", value)
+	}
+	return id, nil
+}
+
+// Int64 returns a path parameter decoded as an int64 type
+func (o *Obfuscator) Int64(r *http.Request, key string) (int64, error) {
+	return pathID(r, key, o.Decode)
+}
+
+// QueryInt64Array returns a slice of query parameters decoded as int64 type
+func (o *Obfuscator) QueryInt64Array(r *http.Request, key string) ([]int64, error) {
+	return queryIDArray(r, key, o.Decode)
+}
+
+// QueryInt64 returns a query parameter decoded as int64 type
+func (o *Obfuscator) QueryInt64(r *http.Request, key string) (int64, error) {
+	values, err := o.QueryInt64Array(r, key)
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
+
+// Query sets a query parameter with an int64 ID encoded by o
+func (o *Obfuscator) Query(q *Query, key string, id int64) *Query {
+	return q.set(key, []string{o.Encode(id)})
+}
+
+// QueryArray sets a slice of query parameters with int64 IDs encoded by o
+func (o *Obfuscator) QueryArray(q *Query, key string, ids []int64) *Query {
+	out := make([]string, len(ids))
+	for index, id := range ids {
+		out[index] = o.Encode(id)
+	}
+	return q.set(key, out)
+}
+
+// ObfuscatedInt64 returns a path parameter decoded by DefaultObfuscator as an int64 type
+func ObfuscatedInt64(r *http.Request, key string) (int64, error) {
+	if DefaultObfuscator == nil {
+		return 0, ErrNoObfuscator
+	}
+	return DefaultObfuscator.Int64(r, key)
+}
+
+// QueryObfuscatedInt64Array returns a slice of query parameters decoded by DefaultObfuscator
+func QueryObfuscatedInt64Array(r *http.Request, key string) ([]int64, error) {
+	if DefaultObfuscator == nil {
+		return nil, ErrNoObfuscator
+	}
+	return DefaultObfuscator.QueryInt64Array(r, key)
+}
+
+// QueryObfuscatedInt64 returns a query parameter decoded by DefaultObfuscator
+func QueryObfuscatedInt64(r *http.Request, key string) (int64, error) {
+	if DefaultObfuscator == nil {
+		return 0, ErrNoObfuscator
+	}
+	return DefaultObfuscator.QueryInt64(r, key)
+}
@@ -0,0 +1,129 @@
+package param
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestObfuscator(t *testing.T) {
+	o, err := NewObfuscator(DefaultAlphabet, "this is my salt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[string]bool{}
+	for _, id := range []int64{0, 1, 2, 3, 99, 100, 12345, -1, math.MaxInt64, math.MinInt64} {
+		encoded := o.Encode(id)
+		if seen[encoded] {
+			t.Fatalf("%d: duplicate encoding %s", id, encoded)
+		}
+		seen[encoded] = true
+
+		got, err := o.Decode(encoded)
+		if err != nil {
+			t.Fatalf("%d: %v", id, err)
+		}
+
+		if got != id {
+			t.Fatalf("want %d, got %d from %s", id, got, encoded)
+		}
+	}
+
+	unsalted, _ := NewObfuscator(DefaultAlphabet, "")
+	if o.Encode(1) == unsalted.Encode(1) {
+		t.Fatal("expected the salt to change the encoding")
+	}
+}
+
+func TestObfuscatorDecodeErr(t *testing.T) {
+	o, _ := NewObfuscator(DefaultAlphabet, "salt")
+	encoded := o.Encode(12345)
+
+	// change each character in turn
+	for i := range encoded {
+		tampered := []byte(encoded)
+		tampered[i] = 'a' + (tampered[i]-'a'+1)%26
+		if tampered[i] == encoded[i] {
+			continue
+		}
+		if id, err := o.Decode(string(tampered)); err == nil && id == 12345 {
+			t.Fatalf("%s decodes to the original ID", tampered)
+		}
+	}
+
+	for _, value := range []string{"", "a", "!!", "zzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, err := o.Decode(value); !errors.Is(err, ErrMalformedID) {
+			t.Fatalf("%q: want ErrMalformedID, got %v", value, err)
+		}
+	}
+
+	if _, err := NewObfuscator("abcabcabcabcabcabc", ""); !errors.Is(err, ErrInvalidAlphabet) {
+		t.Fatalf("want ErrInvalidAlphabet, got %v", err)
+	}
+}
+
+// withDefaultObfuscator sets DefaultObfuscator for the length of a test
+func withDefaultObfuscator(t *testing.T, salt string) *Obfuscator {
+	o, err := NewObfuscator(DefaultAlphabet, salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	DefaultObfuscator = o
+	t.Cleanup(func() { DefaultObfuscator = nil })
+	return o
+}
+
+func TestObfuscatedInt64(t *testing.T) {
+	req, key := newParamRequest(t, "42")
+	if _, err := ObfuscatedInt64(req, key); err != ErrNoObfuscator {
+		t.Fatalf("want ErrNoObfuscator, got %v", err)
+	}
+
+	o := withDefaultObfuscator(t, "path salt")
+	path, err := BuildPath("/users/{id}", map[string]interface{}{"id": o.Encode(42)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, key = newParamRequest(t, path[len("/users/"):])
+	got, err := ObfuscatedInt64(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != 42 {
+		t.Fatalf("want 42, got %d", got)
+	}
+
+	req, key = newParamRequest(t, "42")
+	_, err = ObfuscatedInt64(req, key)
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestQueryObfuscatedInt64(t *testing.T) {
+	o := withDefaultObfuscator(t, "query salt")
+	q := o.Query(o.QueryArray(NewQuery(), "id", []int64{7, 8}), "owner", 3)
+	req := newQueryRequest(t, q.Encode())
+
+	ids, err := QueryObfuscatedInt64Array(req, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 2 || ids[0] != 7 || ids[1] != 8 {
+		t.Fatalf("unexpected values %v", ids)
+	}
+
+	owner, err := QueryObfuscatedInt64(req, "owner")
+	if err != nil || owner != 3 {
+		t.Fatalf("want 3, got %d, %v", owner, err)
+	}
+
+	other, _ := NewObfuscator(DefaultAlphabet, "other salt")
+	if _, err := other.QueryInt64(req, "owner"); !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam for another salt, got %v", err)
+	}
+}
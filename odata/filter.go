@@ -0,0 +1,382 @@
+package odata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oceanicdev/chi-param"
+)
+
+var comparisonOps = map[string]param.FilterOp{
+	"eq": param.FilterEq,
+	"ne": param.FilterNe,
+	"lt": param.FilterLt,
+	"le": param.FilterLe,
+	"gt": param.FilterGt,
+	"ge": param.FilterGe,
+}
+
+var functionOps = map[string]param.FilterOp{
+	"contains":   param.FilterContains,
+	"startswith": param.FilterStartsWith,
+	"endswith":   param.FilterEndsWith,
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenLiteral
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+// ParseFilter parses a $filter expression into the param filter tree and
+// type-checks it against the schema. Comparisons are written as
+// "field op literal", with eq, ne, lt, le, gt, ge and in, combined with
+// and, or, not and parentheses. The contains, startswith and endswith
+// functions take a field and a string. Errors are of type *param.FilterError.
+// Nesting and length are limited as described by param.FilterLimits, "not"
+// counts as a level of nesting.
+func ParseFilter(expr string, schema param.FilterSchema, opts ...param.FilterOption) (param.FilterExpr, error) {
+	maxDepth, maxLength := param.FilterLimits(opts...)
+	if len(expr) > maxLength {
+		return nil, &param.FilterError{Pos: maxLength, Msg: fmt.Sprintf("longer than %d bytes", maxLength), Err: param.ErrFilterTooComplex}
+	}
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, schema: schema, maxDepth: maxDepth}
+	out, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, errorf(t.pos, "unexpected %q", t.text)
+	}
+	return out, nil
+}
+
+func errorf(pos int, format string, args ...interface{}) *param.FilterError {
+	return &param.FilterError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func tokenize(input string) ([]token, error) {
+	var out []token
+	for pos := 0; pos < len(input); {
+		c := input[pos]
+		switch {
+		case c == ' ' || c == '\t':
+			pos++
+		case c == '(':
+			out = append(out, token{kind: tokenOpen, text: "(", pos: pos})
+			pos++
+		case c == ')':
+			out = append(out, token{kind: tokenClose, text: ")", pos: pos})
+			pos++
+		case c == ',':
+			out = append(out, token{kind: tokenComma, text: ",", pos: pos})
+			pos++
+		case c == '\'':
+			// quotes inside strings are escaped by doubling them
+			var b strings.Builder
+			start, closed := pos, false
+			for pos++; pos < len(input); pos++ {
+				if input[pos] == '\'' {
+					if pos+1 < len(input) && input[pos+1] == '\'' {
+						b.WriteByte('\'')
+						pos++
+						continue
+					}
+					pos++
+					closed = true
+					break
+				}
+				b.WriteByte(input[pos])
+			}
+			if !closed {
+				return nil, errorf(start, "unterminated string")
+			}
+			out = append(out, token{kind: tokenString, text: input[start:pos], value: b.String(), pos: start})
+		default:
+			start := pos
+			for pos < len(input) && strings.IndexByte(" \t(),'", input[pos]) < 0 {
+				pos++
+			}
+			text, value := input[start:pos], input[start:pos]
+			// an unencoded + in a time offset reaches us as a space
+			if n := plusOffset(text, input[pos:]); n > 0 {
+				value += "+" + input[pos+1:pos+n]
+				pos += n
+				text = input[start:pos]
+			}
+			kind := tokenLiteral
+			if isIdentStart(text[0]) {
+				kind = tokenIdent
+			}
+			out = append(out, token{kind: kind, text: text, value: value, pos: start})
+		}
+	}
+	return append(out, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+// plusOffset returns the length of a " hh:mm" offset following a date and
+// time literal, or 0
+func plusOffset(literal, rest string) int {
+	if len(literal) < 19 || (literal[10] != 'T' && literal[10] != 't') || len(rest) < 6 || rest[0] != ' ' {
+		return 0
+	}
+	offset := rest[1:6]
+	if !isDigit(offset[0]) || !isDigit(offset[1]) || offset[2] != ':' || !isDigit(offset[3]) || !isDigit(offset[4]) {
+		return 0
+	}
+	if len(rest) > 6 && strings.IndexByte(" \t(),'", rest[6]) < 0 {
+		return 0
+	}
+	return 6
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+type parser struct {
+	tokens   []token
+	pos      int
+	schema   param.FilterSchema
+	depth    int
+	maxDepth int
+}
+
+// enter descends one level of nesting, the caller leaves it with p.depth--
+func (p *parser) enter(pos int) error {
+	if p.depth >= p.maxDepth {
+		return &param.FilterError{Pos: pos, Msg: fmt.Sprintf("nested deeper than %d levels", p.maxDepth), Err: param.ErrFilterTooComplex}
+	}
+	p.depth++
+	return nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokenIdent && t.text == word {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) or() (param.FilterExpr, error) {
+	var exprs []param.FilterExpr
+	for {
+		expr, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if !p.keyword("or") {
+			break
+		}
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return param.FilterOr{Exprs: exprs}, nil
+}
+
+func (p *parser) and() (param.FilterExpr, error) {
+	var exprs []param.FilterExpr
+	for {
+		expr, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if !p.keyword("and") {
+			break
+		}
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return param.FilterAnd{Exprs: exprs}, nil
+}
+
+func (p *parser) not() (param.FilterExpr, error) {
+	if t := p.peek(); p.keyword("not") {
+		if err := p.enter(t.pos); err != nil {
+			return nil, err
+		}
+		expr, err := p.not()
+		p.depth--
+		if err != nil {
+			return nil, err
+		}
+		return param.FilterNot{Expr: expr}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (param.FilterExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenOpen:
+		if err := p.enter(t.pos); err != nil {
+			return nil, err
+		}
+		expr, err := p.or()
+		p.depth--
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokenClose {
+			return nil, errorf(t.pos, "unclosed group")
+		}
+		return expr, nil
+	case tokenIdent:
+		if op, ok := functionOps[t.text]; ok && p.peek().kind == tokenOpen {
+			return p.function(t, op)
+		}
+		return p.comparison(t)
+	}
+	return nil, errorf(t.pos, "expected expression")
+}
+
+func (p *parser) field(t token) (param.FilterType, error) {
+	typ, ok := p.schema[t.text]
+	if !ok {
+		return 0, errorf(t.pos, "unknown field %s", t.text)
+	}
+	return typ, nil
+}
+
+func (p *parser) comparison(field token) (param.FilterExpr, error) {
+	typ, err := p.field(field)
+	if err != nil {
+		return nil, err
+	}
+
+	opToken := p.next()
+	if opToken.kind != tokenIdent {
+		return nil, errorf(opToken.pos, "expected operator")
+	}
+	if opToken.text == "in" {
+		return p.in(field, typ)
+	}
+	op, ok := comparisonOps[opToken.text]
+	if !ok {
+		return nil, errorf(opToken.pos, "unknown operator %s", opToken.text)
+	}
+	if !param.FilterOpSupported(typ, op) {
+		return nil, errorf(opToken.pos, "operator %s is not supported for field %s", opToken.text, field.text)
+	}
+
+	value, err := p.literal(field.text, typ, op == param.FilterEq || op == param.FilterNe)
+	if err != nil {
+		return nil, err
+	}
+	return param.FilterComparison{Field: field.text, Type: typ, Op: op, Values: []interface{}{value}, Pos: field.pos}, nil
+}
+
+func (p *parser) in(field token, typ param.FilterType) (param.FilterExpr, error) {
+	if t := p.next(); t.kind != tokenOpen {
+		return nil, errorf(t.pos, "expected '('")
+	}
+	var values []interface{}
+	for {
+		value, err := p.literal(field.text, typ, false)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		t := p.next()
+		if t.kind == tokenClose {
+			break
+		}
+		if t.kind != tokenComma {
+			return nil, errorf(t.pos, "expected ',' or ')'")
+		}
+	}
+	return param.FilterComparison{Field: field.text, Type: typ, Op: param.FilterIn, Values: values, Pos: field.pos}, nil
+}
+
+func (p *parser) function(name token, op param.FilterOp) (param.FilterExpr, error) {
+	p.next()
+	field := p.next()
+	if field.kind != tokenIdent {
+		return nil, errorf(field.pos, "expected field")
+	}
+	typ, err := p.field(field)
+	if err != nil {
+		return nil, err
+	}
+	if !param.FilterOpSupported(typ, op) {
+		return nil, errorf(name.pos, "function %s is not supported for field %s", name.text, field.text)
+	}
+	if t := p.next(); t.kind != tokenComma {
+		return nil, errorf(t.pos, "expected ','")
+	}
+	value, err := p.literal(field.text, typ, false)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.next(); t.kind != tokenClose {
+		return nil, errorf(t.pos, "expected ')'")
+	}
+	return param.FilterComparison{Field: field.text, Type: typ, Op: op, Values: []interface{}{value}, Pos: name.pos}, nil
+}
+
+// literal reads an operand and converts it to the field type, strings
+// must be quoted and other types must not be
+func (p *parser) literal(field string, typ param.FilterType, nullable bool) (interface{}, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenIdent && t.text == "null":
+		if !nullable {
+			return nil, errorf(t.pos, "null is not allowed here")
+		}
+		return nil, nil
+	case typ == param.FilterString:
+		if t.kind != tokenString {
+			return nil, errorf(t.pos, "expected string for field %s", field)
+		}
+		return t.value, nil
+	case t.kind != tokenLiteral && t.kind != tokenIdent:
+		return nil, errorf(t.pos, "expected value for field %s", field)
+	case typ == param.FilterBool && t.text != "true" && t.text != "false":
+		return nil, &param.FilterError{Pos: t.pos, Msg: "invalid value for field " + field, Err: strconv.ErrSyntax}
+	}
+	v, err := param.ConvertFilterValue(typ, t.value)
+	if err != nil {
+		return nil, &param.FilterError{Pos: t.pos, Msg: "invalid value for field " + field, Err: err}
+	}
+	return v, nil
+}
@@ -0,0 +1,219 @@
+// Package odata reads OData system query options ($filter, $orderby, $top,
+// $skip, $select, $expand and $count) from a request and validates them
+// against an allow-listed model.
+package odata
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/oceanicdev/chi-param"
+)
+
+// Model declares what a client may query. Fields are the filterable,
+// orderable and selectable properties, Expand the navigation properties
+// that can be expanded. A zero MaxTop or MaxSkip disables the bound.
+type Model struct {
+	Fields param.FilterSchema
+	Expand []string
+	MaxTop int
+	// DefaultTop is used when $top is absent, zero leaves Top unset
+	DefaultTop int
+	MaxSkip    int
+	// Filter sets the nesting and length limits of $filter
+	Filter []param.FilterOption
+}
+
+// Options are the system query options of a request. Filter is nil and
+// Select and Expand are empty when the options are absent, an empty Select
+// selects all properties. Top is valid when given by $top or by the model
+// default, only the former marks it as set.
+type Options struct {
+	Filter  param.FilterExpr
+	OrderBy []param.SortTerm
+	Top     param.Null[int]
+	Skip    int
+	Select  []string
+	Expand  []string
+	Count   bool
+}
+
+var systemOptions = map[string]bool{
+	"$filter":  true,
+	"$orderby": true,
+	"$top":     true,
+	"$skip":    true,
+	"$select":  true,
+	"$expand":  true,
+	"$count":   true,
+}
+
+// Parse reads the system query options of a request. Unknown or repeated
+// $-prefixed options are rejected, errors are of type *param.Error.
+func Parse(r *http.Request, model Model) (Options, error) {
+	var out Options
+	query := r.URL.Query()
+	for key, values := range query {
+		if !strings.HasPrefix(key, "$") {
+			continue
+		}
+		if !systemOptions[key] {
+			return Options{}, invalid(key, strings.Join(values, ","), "unsupported system query option")
+		}
+		if len(values) > 1 {
+			return Options{}, invalid(key, strings.Join(values, ","), "option is repeated")
+		}
+	}
+
+	if value, ok := lookup(query, "$filter"); ok {
+		filter, err := ParseFilter(value, model.Fields, model.Filter...)
+		if err != nil {
+			return Options{}, &param.Error{Key: "$filter", Value: value, Err: err}
+		}
+		out.Filter = filter
+	}
+
+	if value, ok := lookup(query, "$orderby"); ok {
+		orderBy, err := parseOrderBy(value, model.Fields)
+		if err != nil {
+			return Options{}, err
+		}
+		out.OrderBy = orderBy
+	}
+
+	if value, ok := lookup(query, "$top"); ok {
+		top, err := boundedInt("$top", value, model.MaxTop)
+		if err != nil {
+			return Options{}, err
+		}
+		out.Top = param.Null[int]{V: top, Valid: true, Set: true}
+	} else if model.DefaultTop > 0 {
+		out.Top = param.Null[int]{V: model.DefaultTop, Valid: true}
+	}
+
+	if value, ok := lookup(query, "$skip"); ok {
+		skip, err := boundedInt("$skip", value, model.MaxSkip)
+		if err != nil {
+			return Options{}, err
+		}
+		out.Skip = skip
+	}
+
+	if value, ok := lookup(query, "$select"); ok && value != "*" {
+		selected, err := parseList("$select", value, func(name string) bool {
+			_, ok := model.Fields[name]
+			return ok
+		})
+		if err != nil {
+			return Options{}, err
+		}
+		out.Select = selected
+	}
+
+	if value, ok := lookup(query, "$expand"); ok {
+		allowed := make(map[string]bool, len(model.Expand))
+		for _, name := range model.Expand {
+			allowed[name] = true
+		}
+		expand, err := parseList("$expand", value, func(name string) bool {
+			return allowed[name]
+		})
+		if err != nil {
+			return Options{}, err
+		}
+		out.Expand = expand
+	}
+
+	if value, ok := lookup(query, "$count"); ok {
+		switch value {
+		case "true":
+			out.Count = true
+		case "false":
+		default:
+			return Options{}, invalid("$count", value, "must be true or false")
+		}
+	}
+	return out, nil
+}
+
+func lookup(query map[string][]string, key string) (string, bool) {
+	values, ok := query[key]
+	if !ok || len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+func invalid(key, value, reason string) *param.Error {
+	return &param.Error{Key: key, Value: value, Reason: reason}
+}
+
+// boundedInt parses a non-negative integer no larger than max, a zero max
+// disables the bound
+func boundedInt(key, value string, max int) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &param.Error{Key: key, Value: value, Err: err}
+	}
+	if v < 0 {
+		return 0, invalid(key, value, "must not be negative")
+	}
+	if max > 0 && v > max {
+		return 0, invalid(key, value, fmt.Sprintf("must not exceed %d", max))
+	}
+	return v, nil
+}
+
+// parseList splits a comma separated list of names, rejecting names not
+// allowed and names listed twice
+func parseList(key, value string, allowed func(string) bool) ([]string, error) {
+	var out []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case len(name) == 0:
+			return nil, invalid(key, value, "empty item")
+		case !allowed(name):
+			return nil, invalid(key, value, "unknown property "+name)
+		case seen[name]:
+			return nil, invalid(key, value, "duplicate property "+name)
+		}
+		seen[name] = true
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+func parseOrderBy(value string, fields param.FilterSchema) ([]param.SortTerm, error) {
+	var out []param.SortTerm
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, invalid("$orderby", value, fmt.Sprintf("invalid item %q", item))
+		}
+
+		term := param.SortTerm{Field: parts[0]}
+		if len(parts) == 2 {
+			switch parts[1] {
+			case "asc":
+			case "desc":
+				term.Desc = true
+			default:
+				return nil, invalid("$orderby", value, "invalid direction "+parts[1])
+			}
+		}
+		if _, ok := fields[term.Field]; !ok {
+			return nil, invalid("$orderby", value, "unknown property "+term.Field)
+		}
+		if seen[term.Field] {
+			return nil, invalid("$orderby", value, "duplicate property "+term.Field)
+		}
+		seen[term.Field] = true
+		out = append(out, term)
+	}
+	return out, nil
+}
@@ -0,0 +1,216 @@
+package odata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oceanicdev/chi-param"
+)
+
+var model = Model{
+	Fields: param.FilterSchema{
+		"name":     param.FilterString,
+		"price":    param.FilterFloat,
+		"stock":    param.FilterInt,
+		"active":   param.FilterBool,
+		"created":  param.FilterTime,
+		"owner/id": param.FilterInt,
+	},
+	Expand:     []string{"owner", "tags"},
+	MaxTop:     100,
+	DefaultTop: 20,
+	MaxSkip:    1000,
+}
+
+func newRequest(t *testing.T, query url.Values) *http.Request {
+	t.Helper()
+
+	return httptest.NewRequest("GET", "/products?"+query.Encode(), nil)
+}
+
+func TestParse(t *testing.T) {
+	req := newRequest(t, url.Values{
+		"$filter":  {"price gt 10 and (active eq true or contains(name, 'o''k'))"},
+		"$orderby": {"price desc, name"},
+		"$top":     {"50"},
+		"$skip":    {"100"},
+		"$select":  {"name,price"},
+		"$expand":  {"owner"},
+		"$count":   {"true"},
+		"page":     {"ignored"},
+	})
+
+	got, err := Parse(req, model)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Options{
+		Filter: param.FilterAnd{Exprs: []param.FilterExpr{
+			param.FilterComparison{Field: "price", Type: param.FilterFloat, Op: param.FilterGt, Values: []interface{}{float64(10)}, Pos: 0},
+			param.FilterOr{Exprs: []param.FilterExpr{
+				param.FilterComparison{Field: "active", Type: param.FilterBool, Op: param.FilterEq, Values: []interface{}{true}, Pos: 17},
+				param.FilterComparison{Field: "name", Type: param.FilterString, Op: param.FilterContains, Values: []interface{}{"o'k"}, Pos: 35},
+			}},
+		}},
+		OrderBy: []param.SortTerm{{Field: "price", Desc: true}, {Field: "name"}},
+		Top:     param.Null[int]{V: 50, Valid: true, Set: true},
+		Skip:    100,
+		Select:  []string{"name", "price"},
+		Expand:  []string{"owner"},
+		Count:   true,
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %#v, got %#v", want, got)
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	got, err := Parse(newRequest(t, url.Values{"$select": {"*"}}), model)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Options{Top: param.Null[int]{V: 20, Valid: true}}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %#v, got %#v", want, got)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want param.FilterExpr
+	}{
+		{"not (stock le 0)", param.FilterNot{Expr: param.FilterComparison{Field: "stock", Type: param.FilterInt, Op: param.FilterLe, Values: []interface{}{int64(0)}, Pos: 5}}},
+		{"name in ('a', 'b')", param.FilterComparison{Field: "name", Type: param.FilterString, Op: param.FilterIn, Values: []interface{}{"a", "b"}, Pos: 0}},
+		{"owner/id eq null", param.FilterComparison{Field: "owner/id", Type: param.FilterInt, Op: param.FilterEq, Values: []interface{}{nil}, Pos: 0}},
+		{"created ge 2024-01-02T03:04:05Z", param.FilterComparison{Field: "created", Type: param.FilterTime, Op: param.FilterGe, Values: []interface{}{created}, Pos: 0}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFilter(tt.expr, model.Fields)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.expr, err)
+		}
+
+		if !reflect.DeepEqual(tt.want, got) {
+			t.Fatalf("%s: want %#v, got %#v", tt.expr, tt.want, got)
+		}
+	}
+}
+
+func TestParseFilterPlus(t *testing.T) {
+	// the + of the offset was decoded to a space
+	got, err := ParseFilter("(created ge 2024-01-02T05:04:05 02:00)", model.Fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created := got.(param.FilterComparison).Values[0].(time.Time)
+	if !created.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("unexpected time %v", created)
+	}
+}
+
+func TestParseFilterErr(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{"", 0},
+		{"secret eq 1", 0},
+		{"price", 5},
+		{"price like 1", 6},
+		{"price gt 'ten'", 9},
+		{"stock eq 1.5", 9},
+		{"name eq bob", 8},
+		{"active gt true", 7},
+		{"active eq yes", 10},
+		{"price gt null", 9},
+		{"contains(price, '1')", 0},
+		{"(price gt 1", 0},
+		{"price gt 1 price", 11},
+		{"name eq 'open", 8},
+		{"name in ('a' 'b')", 13},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseFilter(tt.expr, model.Fields)
+
+		var filterErr *param.FilterError
+		if !errors.As(err, &filterErr) {
+			t.Fatalf("%q: want *param.FilterError, got %v", tt.expr, err)
+		}
+		if filterErr.Pos != tt.pos {
+			t.Fatalf("%q: want position %d, got %d (%v)", tt.expr, tt.pos, filterErr.Pos, err)
+		}
+	}
+}
+
+func TestParseFilterLimits(t *testing.T) {
+	deep := strings.Repeat("not ", 200000) + "active eq true"
+	_, err := ParseFilter(deep, model.Fields, param.MaxLength(len(deep)))
+	if !errors.Is(err, param.ErrFilterTooComplex) {
+		t.Fatalf("want ErrFilterTooComplex, got %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/?"+url.Values{"$filter": {deep}}.Encode(), nil)
+	_, err = Parse(req, model)
+	if !errors.Is(err, param.ErrInvalidParam) || !errors.Is(err, param.ErrFilterTooComplex) {
+		t.Fatalf("want ErrFilterTooComplex, got %v", err)
+	}
+
+	nested := "not ((active eq true))"
+	if _, err := ParseFilter(nested, model.Fields, param.MaxDepth(3)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseFilter(nested, model.Fields, param.MaxDepth(2)); !errors.Is(err, param.ErrFilterTooComplex) {
+		t.Fatalf("want ErrFilterTooComplex, got %v", err)
+	}
+}
+
+func TestParseErr(t *testing.T) {
+	tests := []url.Values{
+		{"$filter": {"price gt"}},
+		{"$top": {"500"}},
+		{"$top": {"-1"}},
+		{"$top": {"ten"}},
+		{"$skip": {"5000"}},
+		{"$orderby": {"secret"}},
+		{"$orderby": {"name up"}},
+		{"$orderby": {"name,name desc"}},
+		{"$orderby": {"name,"}},
+		{"$select": {"name,secret"}},
+		{"$select": {"name,name"}},
+		{"$expand": {"orders"}},
+		{"$count": {"yes"}},
+		{"$search": {"blue"}},
+		{"$top": {"1", "2"}},
+	}
+
+	for _, query := range tests {
+		_, err := Parse(newRequest(t, query), model)
+		if !errors.Is(err, param.ErrInvalidParam) {
+			t.Fatalf("%v: want param.ErrInvalidParam, got %v", query, err)
+		}
+
+		var paramErr *param.Error
+		if !errors.As(err, &paramErr) {
+			t.Fatalf("%v: want *param.Error, got %T", query, err)
+		}
+	}
+
+	_, err := Parse(newRequest(t, url.Values{"$top": {"ten"}}), model)
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("want strconv.ErrSyntax, got %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package param
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PaginationStyle selects the parameters a listing is paginated with
+type PaginationStyle int
+
+const (
+	// OffsetPagination reads limit and offset parameters
+	OffsetPagination PaginationStyle = iota
+	// PagePagination reads page number and page size parameters
+	PagePagination
+	// CursorPagination reads an opaque cursor and limit parameters
+	CursorPagination
+)
+
+// DefaultPageSize is the page size used when neither the request nor the options set one
+const DefaultPageSize = 20
+
+// PaginationOptions configures Pagination. Empty keys fall back to
+// "limit", "offset", "page", "per_page" and "cursor", a zero DefaultLimit
+// to DefaultPageSize and a zero MaxLimit or MaxOffset disables the upper bound.
+// In the page number style MaxOffset limits the page to the last one that
+// starts within it.
+type PaginationOptions struct {
+	Style        PaginationStyle
+	LimitKey     string
+	OffsetKey    string
+	PageKey      string
+	CursorKey    string
+	DefaultLimit int
+	MaxLimit     int
+	MaxOffset    int
+	// Reject returns an error for out of range values instead of clamping them
+	Reject bool
+}
+
+func (o PaginationOptions) withDefaults() PaginationOptions {
+	if len(o.LimitKey) == 0 {
+		o.LimitKey = "limit"
+		if o.Style == PagePagination {
+			o.LimitKey = "per_page"
+		}
+	}
+	if len(o.OffsetKey) == 0 {
+		o.OffsetKey = "offset"
+	}
+	if len(o.PageKey) == 0 {
+		o.PageKey = "page"
+	}
+	if len(o.CursorKey) == 0 {
+		o.CursorKey = "cursor"
+	}
+	if o.DefaultLimit <= 0 {
+		o.DefaultLimit = DefaultPageSize
+	}
+	if o.MaxLimit > 0 && o.DefaultLimit > o.MaxLimit {
+		o.DefaultLimit = o.MaxLimit
+	}
+	return o
+}
+
+// Page is a pagination request. Offset is set for offset and page number
+// styles, Number only for the page number style and Cursor only for the
+// cursor style. NextCursor and PrevCursor are filled in by the handler
+// before building links for cursor pagination.
+type Page struct {
+	Style      PaginationStyle
+	Limit      int
+	Offset     int
+	Number     int
+	Cursor     string
+	NextCursor string
+	PrevCursor string
+
+	opts PaginationOptions
+}
+
+// Pagination returns the pagination parameters of a request
+func Pagination(r *http.Request, opts PaginationOptions) (Page, error) {
+	opts = opts.withDefaults()
+	p := Page{Style: opts.Style, opts: opts}
+
+	limit, err := paginationInt(r, opts.LimitKey, opts.DefaultLimit, 1, opts.MaxLimit, opts.Reject)
+	if err != nil {
+		return Page{}, err
+	}
+	p.Limit = limit
+
+	switch opts.Style {
+	case OffsetPagination:
+		offset, err := paginationInt(r, opts.OffsetKey, 0, 0, opts.MaxOffset, opts.Reject)
+		if err != nil {
+			return Page{}, err
+		}
+		p.Offset = offset
+	case PagePagination:
+		// MaxOffset bounds the page number, to the last page starting within it
+		maxNumber := 0
+		if opts.MaxOffset > 0 {
+			maxNumber = opts.MaxOffset/limit + 1
+		}
+		number, err := paginationInt(r, opts.PageKey, 1, 1, maxNumber, opts.Reject)
+		if err != nil {
+			return Page{}, err
+		}
+		if number-1 > math.MaxInt/limit {
+			value, _ := queryValue(r, opts.PageKey)
+			return Page{}, invalidParam(opts.PageKey, value, "page is out of range")
+		}
+		p.Number = number
+		p.Offset = (number - 1) * limit
+	case CursorPagination:
+		p.Cursor, _ = queryValue(r, opts.CursorKey)
+	default:
+		return Page{}, fmt.Errorf("unknown pagination style %d", opts.Style)
+	}
+	return p, nil
+}
+
+// paginationInt reads an integer query parameter and clamps or rejects it
+// outside of [min, max], a zero max disables the upper bound
+func paginationInt(r *http.Request, key string, def, min, max int, reject bool) (int, error) {
+	value, ok := queryValue(r, key)
+	if !ok || len(value) == 0 {
+		return def, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, wrapParam(key, value, err)
+	}
+	switch {
+	case v < min:
+		if reject {
+			return 0, invalidParam(key, value, fmt.Sprintf("must be at least %d", min))
+		}
+		v = min
+	case max > 0 && v > max:
+		if reject {
+			return 0, invalidParam(key, value, fmt.Sprintf("must not exceed %d", max))
+		}
+		v = max
+	}
+	return v, nil
+}
+
+// HasPrev reports whether a previous page exists
+func (p Page) HasPrev() bool {
+	if p.Style == CursorPagination {
+		return len(p.PrevCursor) > 0
+	}
+	return p.Offset > 0
+}
+
+// lastPossible reports whether the next offset or page number would overflow
+func (p Page) lastPossible() bool {
+	switch p.Style {
+	case OffsetPagination:
+		return p.Offset > math.MaxInt-p.Limit
+	case PagePagination:
+		return p.Number == math.MaxInt || p.Offset > math.MaxInt-p.Limit
+	}
+	return false
+}
+
+// NextURL returns u pointing at the following page, other query parameters
+// are kept. On a page whose next offset would overflow it returns u
+// unchanged, Link leaves the next relation out there.
+func (p Page) NextURL(u *url.URL) *url.URL {
+	if p.lastPossible() {
+		return u
+	}
+	q := NewQuery()
+	q.values = u.Query()
+	switch p.Style {
+	case OffsetPagination:
+		q.Int(p.opts.OffsetKey, p.Offset+p.Limit)
+	case PagePagination:
+		q.Int(p.opts.PageKey, p.Number+1)
+	case CursorPagination:
+		q.String(p.opts.CursorKey, p.NextCursor)
+	}
+	q.Int(p.opts.LimitKey, p.Limit)
+	return withQuery(u, q)
+}
+
+// PrevURL returns u pointing at the preceding page, other query parameters are kept
+func (p Page) PrevURL(u *url.URL) *url.URL {
+	q := NewQuery()
+	q.values = u.Query()
+	switch p.Style {
+	case OffsetPagination:
+		offset := p.Offset - p.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		q.Int(p.opts.OffsetKey, offset)
+	case PagePagination:
+		q.Int(p.opts.PageKey, p.Number-1)
+	case CursorPagination:
+		q.String(p.opts.CursorKey, p.PrevCursor)
+	}
+	q.Int(p.opts.LimitKey, p.Limit)
+	return withQuery(u, q)
+}
+
+// Link returns an RFC 8288 Link header value with next and prev relations
+// relative to u. hasNext tells whether a following page exists, with
+// cursor pagination it also requires NextCursor to be set. An empty string
+// is returned when there are no other pages.
+func (p Page) Link(u *url.URL, hasNext bool) string {
+	var links []string
+	if hasNext && !p.lastPossible() && (p.Style != CursorPagination || len(p.NextCursor) > 0) {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, p.NextURL(u)))
+	}
+	if p.HasPrev() {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, p.PrevURL(u)))
+	}
+	return strings.Join(links, ", ")
+}
+
+func withQuery(u *url.URL, q *Query) *url.URL {
+	out := *u
+	out.RawQuery = q.Encode()
+	return &out
+}
@@ -0,0 +1,163 @@
+package param
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPaginationOffset(t *testing.T) {
+	req := newQueryRequest(t, "limit=500&offset=40&q=go")
+
+	got, err := Pagination(req, PaginationOptions{MaxLimit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Limit != 100 || got.Offset != 40 {
+		t.Fatalf("want limit 100 offset 40, got %+v", got)
+	}
+
+	want := `</?limit=100&offset=140&q=go>; rel="next", </?limit=100&offset=0&q=go>; rel="prev"`
+	if link := got.Link(req.URL, true); link != want {
+		t.Fatalf("want %s, got %s", want, link)
+	}
+}
+
+func TestPaginationDefaults(t *testing.T) {
+	req := newQueryRequest(t, "")
+
+	got, err := Pagination(req, PaginationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Limit != DefaultPageSize || got.Offset != 0 {
+		t.Fatalf("want limit %d offset 0, got %+v", DefaultPageSize, got)
+	}
+
+	if link := got.Link(req.URL, false); link != "" {
+		t.Fatalf("want no links, got %s", link)
+	}
+}
+
+func TestPaginationOffsetOverflow(t *testing.T) {
+	req := newQueryRequest(t, "offset="+strconv.Itoa(math.MaxInt)+"&limit=10")
+
+	got, err := Pagination(req, PaginationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	link := got.Link(req.URL, true)
+	if strings.Contains(link, `rel="next"`) {
+		t.Fatalf("want no next link, got %s", link)
+	}
+
+	got, err = Pagination(req, PaginationOptions{MaxOffset: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Offset != 1000 {
+		t.Fatalf("want offset clamped to 1000, got %d", got.Offset)
+	}
+}
+
+func TestPaginationPage(t *testing.T) {
+	req := newQueryRequest(t, "page=3&size=10")
+
+	got, err := Pagination(req, PaginationOptions{Style: PagePagination, LimitKey: "size"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Number != 3 || got.Limit != 10 || got.Offset != 20 {
+		t.Fatalf("want page 3 size 10 offset 20, got %+v", got)
+	}
+
+	want := `</?page=4&size=10>; rel="next", </?page=2&size=10>; rel="prev"`
+	if link := got.Link(req.URL, true); link != want {
+		t.Fatalf("want %s, got %s", want, link)
+	}
+}
+
+func TestPaginationPageClamp(t *testing.T) {
+	req := newQueryRequest(t, "page=-2&per_page=0")
+
+	got, err := Pagination(req, PaginationOptions{Style: PagePagination})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Number != 1 || got.Limit != 1 || got.Offset != 0 {
+		t.Fatalf("want page 1 size 1, got %+v", got)
+	}
+}
+
+func TestPaginationPageMaxOffset(t *testing.T) {
+	req := newQueryRequest(t, "page=1000&per_page=10")
+
+	got, err := Pagination(req, PaginationOptions{Style: PagePagination, MaxOffset: 105})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Number != 11 || got.Offset != 100 {
+		t.Fatalf("want page 11 at offset 100, got %+v", got)
+	}
+}
+
+func TestPaginationCursor(t *testing.T) {
+	req := newQueryRequest(t, "cursor=abc&limit=5")
+
+	got, err := Pagination(req, PaginationOptions{Style: CursorPagination})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Cursor != "abc" || got.Limit != 5 {
+		t.Fatalf("want cursor abc limit 5, got %+v", got)
+	}
+
+	got.NextCursor = "def"
+	want := `</?cursor=def&limit=5>; rel="next"`
+	if link := got.Link(req.URL, true); link != want {
+		t.Fatalf("want %s, got %s", want, link)
+	}
+}
+
+func TestPaginationErr(t *testing.T) {
+	tests := []struct {
+		query string
+		opts  PaginationOptions
+	}{
+		{"limit=500", PaginationOptions{MaxLimit: 100, Reject: true}},
+		{"offset=-1", PaginationOptions{Reject: true}},
+		{"offset=1001", PaginationOptions{MaxOffset: 1000, Reject: true}},
+		{"page=0", PaginationOptions{Style: PagePagination, Reject: true}},
+		{"page=1000&per_page=10", PaginationOptions{Style: PagePagination, MaxOffset: 100, Reject: true}},
+		{"page=" + strconv.Itoa(int(^uint(0)>>1)), PaginationOptions{Style: PagePagination}},
+	}
+
+	for _, tt := range tests {
+		_, err := Pagination(newQueryRequest(t, tt.query), tt.opts)
+		if !errors.Is(err, ErrInvalidParam) {
+			t.Fatalf("%s: want ErrInvalidParam, got %v", tt.query, err)
+		}
+
+		var paramErr *Error
+		if !errors.As(err, &paramErr) {
+			t.Fatalf("%s: want *Error, got %T", tt.query, err)
+		}
+	}
+}
+
+func TestPaginationSyntaxErr(t *testing.T) {
+	_, err := Pagination(newQueryRequest(t, "limit=ten"), PaginationOptions{})
+	if !errors.Is(err, strconv.ErrSyntax) || !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want syntax error, got %v", err)
+	}
+}
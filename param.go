@@ -4,7 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -22,60 +22,60 @@ func String(r *http.Request, key string) (string, error) {
 }
 
 // Int returns a path parameter as an int type
-func Int(r *http.Request, key string) (int, error) {
-	return strconv.Atoi(chi.URLParam(r, key))
+func Int(r *http.Request, key string, opts ...IntOption) (int, error) {
+	return atoiOpts(chi.URLParam(r, key), opts)
 }
 
 // Int8 returns a path parameter as an int8 type
-func Int8(r *http.Request, key string) (int8, error) {
-	value, err := strconv.ParseInt(chi.URLParam(r, key), 10, 8)
+func Int8(r *http.Request, key string, opts ...IntOption) (int8, error) {
+	value, err := parseIntOpts(chi.URLParam(r, key), 8, opts)
 	return int8(value), err
 }
 
 // Int16 returns a path parameter as an int16 type
-func Int16(r *http.Request, key string) (int16, error) {
-	value, err := strconv.ParseInt(chi.URLParam(r, key), 10, 16)
+func Int16(r *http.Request, key string, opts ...IntOption) (int16, error) {
+	value, err := parseIntOpts(chi.URLParam(r, key), 16, opts)
 	return int16(value), err
 }
 
 // Int32 returns a path parameter as an int32 type
-func Int32(r *http.Request, key string) (int32, error) {
-	value, err := strconv.ParseInt(chi.URLParam(r, key), 10, 32)
+func Int32(r *http.Request, key string, opts ...IntOption) (int32, error) {
+	value, err := parseIntOpts(chi.URLParam(r, key), 32, opts)
 	return int32(value), err
 }
 
 // Int64 returns a path parameter as an int64 type
-func Int64(r *http.Request, key string) (int64, error) {
-	return strconv.ParseInt(chi.URLParam(r, key), 10, 64)
+func Int64(r *http.Request, key string, opts ...IntOption) (int64, error) {
+	return parseIntOpts(chi.URLParam(r, key), 64, opts)
 }
 
 // Uint returns a path parameter as an uint type
-func Uint(r *http.Request, key string) (uint, error) {
-	value, err := strconv.ParseUint(chi.URLParam(r, key), 10, 32)
+func Uint(r *http.Request, key string, opts ...IntOption) (uint, error) {
+	value, err := parseUintOpts(chi.URLParam(r, key), 32, opts)
 	return uint(value), err
 }
 
 // Uint8 returns a path parameter as an uint8 type
-func Uint8(r *http.Request, key string) (uint8, error) {
-	value, err := strconv.ParseUint(chi.URLParam(r, key), 10, 8)
+func Uint8(r *http.Request, key string, opts ...IntOption) (uint8, error) {
+	value, err := parseUintOpts(chi.URLParam(r, key), 8, opts)
 	return uint8(value), err
 }
 
 // Uint16 returns a path parameter as an uint16 type
-func Uint16(r *http.Request, key string) (uint16, error) {
-	value, err := strconv.ParseUint(chi.URLParam(r, key), 10, 16)
+func Uint16(r *http.Request, key string, opts ...IntOption) (uint16, error) {
+	value, err := parseUintOpts(chi.URLParam(r, key), 16, opts)
 	return uint16(value), err
 }
 
 // Uint32 returns a path parameter as an uint32 type
-func Uint32(r *http.Request, key string) (uint32, error) {
-	value, err := strconv.ParseUint(chi.URLParam(r, key), 10, 32)
+func Uint32(r *http.Request, key string, opts ...IntOption) (uint32, error) {
+	value, err := parseUintOpts(chi.URLParam(r, key), 32, opts)
 	return uint32(value), err
 }
 
 // Uint64 returns a path parameter as an uint64 type
-func Uint64(r *http.Request, key string) (uint64, error) {
-	return strconv.ParseUint(chi.URLParam(r, key), 10, 64)
+func Uint64(r *http.Request, key string, opts ...IntOption) (uint64, error) {
+	return parseUintOpts(chi.URLParam(r, key), 64, opts)
 }
 
 // Bool returns a path parameter as a boolean type
@@ -94,6 +94,12 @@ func Float64(r *http.Request, key string) (float64, error) {
 	return strconv.ParseFloat(chi.URLParam(r, key), 64)
 }
 
+// Time returns a path parameter as a time type, formatted as RFC 3339 or,
+// with the RelativeTime option, as a relative expression
+func Time(r *http.Request, key string, opts ...TimeOption) (time.Time, error) {
+	return timeParser(opts)(chi.URLParam(r, key))
+}
+
 // QueryStringArray returns a slice of query parameters with string type
 func QueryStringArray(r *http.Request, key string) ([]string, error) {
 	values, ok := r.URL.Query()[key]
@@ -104,14 +110,14 @@ func QueryStringArray(r *http.Request, key string) ([]string, error) {
 }
 
 // QueryIntArray returns a slice of query parameters with int type
-func QueryIntArray(r *http.Request, key string) ([]int, error) {
+func QueryIntArray(r *http.Request, key string, opts ...IntOption) ([]int, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]int, len(values))
 	for index, value := range values {
-		v, err := strconv.Atoi(value)
+		v, err := atoiOpts(value, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -121,14 +127,14 @@ func QueryIntArray(r *http.Request, key string) ([]int, error) {
 }
 
 // QueryInt8Array returns a slice of query parameters with int8 type
-func QueryInt8Array(r *http.Request, key string) ([]int8, error) {
+func QueryInt8Array(r *http.Request, key string, opts ...IntOption) ([]int8, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]int8, len(values))
 	for index, value := range values {
-		v, err := strconv.ParseInt(value, 10, 8)
+		v, err := parseIntOpts(value, 8, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -138,14 +144,14 @@ func QueryInt8Array(r *http.Request, key string) ([]int8, error) {
 }
 
 // QueryInt16Array returns a slice of query parameters with int16 type
-func QueryInt16Array(r *http.Request, key string) ([]int16, error) {
+func QueryInt16Array(r *http.Request, key string, opts ...IntOption) ([]int16, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]int16, len(values))
 	for index, value := range values {
-		v, err := strconv.ParseInt(value, 10, 16)
+		v, err := parseIntOpts(value, 16, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -155,14 +161,14 @@ func QueryInt16Array(r *http.Request, key string) ([]int16, error) {
 }
 
 // QueryInt32Array returns a slice of query parameters with int32 type
-func QueryInt32Array(r *http.Request, key string) ([]int32, error) {
+func QueryInt32Array(r *http.Request, key string, opts ...IntOption) ([]int32, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]int32, len(values))
 	for index, value := range values {
-		v, err := strconv.ParseInt(value, 10, 32)
+		v, err := parseIntOpts(value, 32, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -172,14 +178,14 @@ func QueryInt32Array(r *http.Request, key string) ([]int32, error) {
 }
 
 // QueryInt64Array returns a slice of query parameters with int64 type
-func QueryInt64Array(r *http.Request, key string) ([]int64, error) {
+func QueryInt64Array(r *http.Request, key string, opts ...IntOption) ([]int64, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]int64, len(values))
 	for index, value := range values {
-		v, err := strconv.ParseInt(value, 10, 64)
+		v, err := parseIntOpts(value, 64, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -189,14 +195,14 @@ func QueryInt64Array(r *http.Request, key string) ([]int64, error) {
 }
 
 // QueryUintArray returns a slice of query parameters with uint type
-func QueryUintArray(r *http.Request, key string) ([]uint, error) {
+func QueryUintArray(r *http.Request, key string, opts ...IntOption) ([]uint, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]uint, len(values))
 	for index, value := range values {
-		v, err := strconv.ParseUint(value, 10, 32)
+		v, err := parseUintOpts(value, 32, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -206,14 +212,14 @@ func QueryUintArray(r *http.Request, key string) ([]uint, error) {
 }
 
 // QueryUint8Array returns a slice of query parameters with uint8 type
-func QueryUint8Array(r *http.Request, key string) ([]uint8, error) {
+func QueryUint8Array(r *http.Request, key string, opts ...IntOption) ([]uint8, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]uint8, len(values))
 	for index, value := range values {
-		v, err := strconv.ParseUint(value, 10, 8)
+		v, err := parseUintOpts(value, 8, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -223,14 +229,14 @@ func QueryUint8Array(r *http.Request, key string) ([]uint8, error) {
 }
 
 // QueryUint16Array returns a slice of query parameters with uint16 type
-func QueryUint16Array(r *http.Request, key string) ([]uint16, error) {
+func QueryUint16Array(r *http.Request, key string, opts ...IntOption) ([]uint16, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]uint16, len(values))
 	for index, value := range values {
-		v, err := strconv.ParseUint(value, 10, 16)
+		v, err := parseUintOpts(value, 16, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -240,14 +246,14 @@ func QueryUint16Array(r *http.Request, key string) ([]uint16, error) {
 }
 
 // QueryUint32Array returns a slice of query parameters with uint32 type
-func QueryUint32Array(r *http.Request, key string) ([]uint32, error) {
+func QueryUint32Array(r *http.Request, key string, opts ...IntOption) ([]uint32, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]uint32, len(values))
 	for index, value := range values {
-		v, err := strconv.ParseUint(value, 10, 32)
+		v, err := parseUintOpts(value, 32, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -257,14 +263,14 @@ func QueryUint32Array(r *http.Request, key string) ([]uint32, error) {
 }
 
 // QueryUint64Array returns a slice of query parameters with uint64 type
-func QueryUint64Array(r *http.Request, key string) ([]uint64, error) {
+func QueryUint64Array(r *http.Request, key string, opts ...IntOption) ([]uint64, error) {
 	values, err := QueryStringArray(r, key)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]uint64, len(values))
 	for index, value := range values {
-		v, err := strconv.ParseUint(value, 10, 64)
+		v, err := parseUintOpts(value, 64, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -298,12 +304,7 @@ func QueryFloat32Array(r *http.Request, key string) ([]float32, error) {
 	}
 	out := make([]float32, len(values))
 	for index, value := range values {
-		// replace + stripped out during url parse stage
-		if strings.Contains(value, " ") {
-			value = strings.Replace(value, " ", "+", 1)
-		}
-
-		v, err := strconv.ParseFloat(value, 32)
+		v, err := strconv.ParseFloat(unplus(value), 32)
 		if err != nil {
 			return nil, err
 		}
@@ -320,12 +321,26 @@ func QueryFloat64Array(r *http.Request, key string) ([]float64, error) {
 	}
 	out := make([]float64, len(values))
 	for index, value := range values {
-		// replace + stripped out during url parse stage
-		if strings.Contains(value, " ") {
-			value = strings.Replace(value, " ", "+", 1)
+		v, err := strconv.ParseFloat(unplus(value), 64)
+		if err != nil {
+			return nil, err
 		}
+		out[index] = v
+	}
+	return out, nil
+}
 
-		v, err := strconv.ParseFloat(value, 64)
+// QueryTimeArray returns a slice of query parameters with time type, formatted as RFC 3339 or,
+// with the RelativeTime option, as relative expressions
+func QueryTimeArray(r *http.Request, key string, opts ...TimeOption) ([]time.Time, error) {
+	values, err := QueryStringArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+	parse := timeParser(opts)
+	out := make([]time.Time, len(values))
+	for index, value := range values {
+		v, err := parse(unplus(value))
 		if err != nil {
 			return nil, err
 		}
@@ -344,8 +359,8 @@ func QueryString(r *http.Request, key string) (string, error) {
 }
 
 // QueryInt returns a query parameter with int type
-func QueryInt(r *http.Request, key string) (int, error) {
-	values, err := QueryIntArray(r, key)
+func QueryInt(r *http.Request, key string, opts ...IntOption) (int, error) {
+	values, err := QueryIntArray(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -353,8 +368,8 @@ func QueryInt(r *http.Request, key string) (int, error) {
 }
 
 // QueryInt8 returns a query parameter with int8 type
-func QueryInt8(r *http.Request, key string) (int8, error) {
-	values, err := QueryInt8Array(r, key)
+func QueryInt8(r *http.Request, key string, opts ...IntOption) (int8, error) {
+	values, err := QueryInt8Array(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -362,8 +377,8 @@ func QueryInt8(r *http.Request, key string) (int8, error) {
 }
 
 // QueryInt16 returns a query parameter with int16 type
-func QueryInt16(r *http.Request, key string) (int16, error) {
-	values, err := QueryInt16Array(r, key)
+func QueryInt16(r *http.Request, key string, opts ...IntOption) (int16, error) {
+	values, err := QueryInt16Array(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -371,8 +386,8 @@ func QueryInt16(r *http.Request, key string) (int16, error) {
 }
 
 // QueryInt32 returns a query parameter with int32 type
-func QueryInt32(r *http.Request, key string) (int32, error) {
-	values, err := QueryInt32Array(r, key)
+func QueryInt32(r *http.Request, key string, opts ...IntOption) (int32, error) {
+	values, err := QueryInt32Array(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -380,8 +395,8 @@ func QueryInt32(r *http.Request, key string) (int32, error) {
 }
 
 // QueryInt64 returns a query parameter with int64 type
-func QueryInt64(r *http.Request, key string) (int64, error) {
-	values, err := QueryInt64Array(r, key)
+func QueryInt64(r *http.Request, key string, opts ...IntOption) (int64, error) {
+	values, err := QueryInt64Array(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -389,8 +404,8 @@ func QueryInt64(r *http.Request, key string) (int64, error) {
 }
 
 // QueryUint returns a query parameter with uint type
-func QueryUint(r *http.Request, key string) (uint, error) {
-	values, err := QueryUintArray(r, key)
+func QueryUint(r *http.Request, key string, opts ...IntOption) (uint, error) {
+	values, err := QueryUintArray(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -398,8 +413,8 @@ func QueryUint(r *http.Request, key string) (uint, error) {
 }
 
 // QueryUint8 returns a query parameter with uint8 type
-func QueryUint8(r *http.Request, key string) (uint8, error) {
-	values, err := QueryUint8Array(r, key)
+func QueryUint8(r *http.Request, key string, opts ...IntOption) (uint8, error) {
+	values, err := QueryUint8Array(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -407,8 +422,8 @@ func QueryUint8(r *http.Request, key string) (uint8, error) {
 }
 
 // QueryUint16 returns a query parameter with uint16 type
-func QueryUint16(r *http.Request, key string) (uint16, error) {
-	values, err := QueryUint16Array(r, key)
+func QueryUint16(r *http.Request, key string, opts ...IntOption) (uint16, error) {
+	values, err := QueryUint16Array(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -416,8 +431,8 @@ func QueryUint16(r *http.Request, key string) (uint16, error) {
 }
 
 // QueryUint32 returns a query parameter with uint32 type
-func QueryUint32(r *http.Request, key string) (uint32, error) {
-	values, err := QueryUint32Array(r, key)
+func QueryUint32(r *http.Request, key string, opts ...IntOption) (uint32, error) {
+	values, err := QueryUint32Array(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -425,8 +440,8 @@ func QueryUint32(r *http.Request, key string) (uint32, error) {
 }
 
 // QueryUint64 returns a query parameter with uint64 type
-func QueryUint64(r *http.Request, key string) (uint64, error) {
-	values, err := QueryUint64Array(r, key)
+func QueryUint64(r *http.Request, key string, opts ...IntOption) (uint64, error) {
+	values, err := QueryUint64Array(r, key, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -459,3 +474,13 @@ func QueryFloat64(r *http.Request, key string) (float64, error) {
 	}
 	return values[0], nil
 }
+
+// QueryTime returns a query parameter with time type, formatted as RFC 3339 or,
+// with the RelativeTime option, as a relative expression
+func QueryTime(r *http.Request, key string, opts ...TimeOption) (time.Time, error) {
+	values, err := QueryTimeArray(r, key, opts...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return values[0], nil
+}
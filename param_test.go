@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -29,6 +30,23 @@ func newParamRequest(t *testing.T, value string) (*http.Request, string) {
 	return r.WithContext(ctx), paramKey
 }
 
+// serveRequest routes target through a chi router with pattern, so path
+// parameters hold what chi really extracts, and returns what get reads
+func serveRequest[T any](t *testing.T, pattern, target string, get func(*http.Request) (T, error)) (T, error) {
+	t.Helper()
+
+	var (
+		got T
+		err error
+	)
+	r := chi.NewRouter()
+	r.Get(pattern, func(w http.ResponseWriter, r *http.Request) {
+		got, err = get(r)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", target, nil))
+	return got, err
+}
+
 func newQueryRequest(t *testing.T, queryString string) *http.Request {
 	t.Helper()
 
@@ -386,6 +404,30 @@ func TestFloat64Err(t *testing.T) {
 	}
 }
 
+func TestTime(t *testing.T) {
+	want := time.Date(2018, 5, 12, 10, 30, 0, 0, time.UTC)
+	req, key := newParamRequest(t, want.Format(time.RFC3339))
+
+	got, err := Time(req, key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestTimeErr(t *testing.T) {
+	req, key := newParamRequest(t, "yesterday")
+	_, err := Time(req, key)
+
+	if err == nil {
+		t.Fatal("Time('yesterday') should throw an error")
+	}
+}
+
 func TestQueryStringArray(t *testing.T) {
 	req := newQueryRequest(t, "fruit=apple&fruit=orange&veggie=pepper")
 
@@ -1103,3 +1145,26 @@ func TestQueryFloat64Err(t *testing.T) {
 		t.Fatal("expected error parsing invalid float64")
 	}
 }
+
+func TestQueryTime(t *testing.T) {
+	want := time.Date(2018, 5, 12, 10, 30, 0, 0, time.UTC)
+	req := newQueryRequest(t, fmt.Sprintf("since=%s", want.Format(time.RFC3339)))
+
+	got, err := QueryTime(req, "since")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !want.Equal(got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestQueryTimeErr(t *testing.T) {
+	req := newQueryRequest(t, fmt.Sprintf("since=%s", "yesterday"))
+
+	_, err := QueryTime(req, "since")
+	if err == nil {
+		t.Fatal("expected error parsing invalid time")
+	}
+}
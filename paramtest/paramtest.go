@@ -0,0 +1,157 @@
+// Package paramtest provides helpers to build requests with chi route
+// parameters and to check errors returned by the param package.
+package paramtest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/oceanicdev/chi-param"
+)
+
+// RequestBuilder builds a request as if chi had routed it to a pattern
+type RequestBuilder struct {
+	method  string
+	pattern string
+	keys    []string
+	values  []string
+	query   url.Values
+	header  http.Header
+	body    io.Reader
+	err     error
+}
+
+// NewRequest returns a builder for a request routed to the given chi pattern
+func NewRequest(method, pattern string) *RequestBuilder {
+	return &RequestBuilder{
+		method:  method,
+		pattern: pattern,
+		query:   url.Values{},
+		header:  http.Header{},
+	}
+}
+
+// Path adds a path parameter
+func (b *RequestBuilder) Path(key, value string) *RequestBuilder {
+	b.keys = append(b.keys, key)
+	b.values = append(b.values, value)
+	return b
+}
+
+// Query adds query parameters, several values are added as repeated keys
+func (b *RequestBuilder) Query(key string, values ...string) *RequestBuilder {
+	for _, value := range values {
+		b.query.Add(key, value)
+	}
+	return b
+}
+
+// Header adds a request header
+func (b *RequestBuilder) Header(key, value string) *RequestBuilder {
+	b.header.Add(key, value)
+	return b
+}
+
+// Body sets the request body
+func (b *RequestBuilder) Body(body io.Reader) *RequestBuilder {
+	b.body = body
+	return b
+}
+
+// Build returns the request with a chi route context holding the path
+// parameters. The URL path is built from the pattern when every route
+// parameter has a value, otherwise the pattern itself is used as the path
+// and Err reports why.
+func (b *RequestBuilder) Build() *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.RoutePatterns = append(rctx.RoutePatterns, b.pattern)
+	values := make(map[string]interface{}, len(b.keys))
+	for index, key := range b.keys {
+		rctx.URLParams.Add(key, b.values[index])
+		values[key] = b.values[index]
+	}
+
+	r := httptest.NewRequest(b.method, "/", b.body)
+	b.err = nil
+	if path, err := param.BuildPath(b.pattern, values); err != nil {
+		b.err = err
+		r.URL.Path = b.pattern
+	} else if u, err := url.Parse(path); err != nil {
+		b.err = err
+		r.URL.Path = b.pattern
+	} else {
+		// BuildPath escapes the values, the URL keeps both forms
+		r.URL.Path, r.URL.RawPath = u.Path, u.RawPath
+	}
+	r.URL.RawQuery = b.query.Encode()
+	r.RequestURI = r.URL.RequestURI()
+	for key, values := range b.header {
+		r.Header[key] = append(r.Header[key], values...)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+// Err returns the error that kept the last Build from filling in the
+// pattern, such as param.ErrMissingParam, or nil
+func (b *RequestBuilder) Err() error {
+	return b.err
+}
+
+// AssertNoError fails the test if err is not nil
+func AssertNoError(t testing.TB, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// AssertErrorIs fails the test if err does not match target
+func AssertErrorIs(t testing.TB, err, target error) {
+	t.Helper()
+
+	if !errors.Is(err, target) {
+		t.Fatalf("got error %v, want %v", err, target)
+	}
+}
+
+// AssertInvalidParam fails the test if err is not param.ErrInvalidParam
+func AssertInvalidParam(t testing.TB, err error) {
+	t.Helper()
+
+	AssertErrorIs(t, err, param.ErrInvalidParam)
+}
+
+// AssertMissingParam fails the test if err is not param.ErrMissingParam
+func AssertMissingParam(t testing.TB, err error) {
+	t.Helper()
+
+	AssertErrorIs(t, err, param.ErrMissingParam)
+}
+
+// AssertSyntaxError fails the test if err is not a malformed value error,
+// either strconv.ErrSyntax or a time parse error
+func AssertSyntaxError(t testing.TB, err error) {
+	t.Helper()
+
+	var timeErr *time.ParseError
+	if errors.Is(err, strconv.ErrSyntax) || errors.As(err, &timeErr) {
+		return
+	}
+	t.Fatalf("got error %v, want syntax error", err)
+}
+
+// AssertRangeError fails the test if err is not strconv.ErrRange
+func AssertRangeError(t testing.TB, err error) {
+	t.Helper()
+
+	AssertErrorIs(t, err, strconv.ErrRange)
+}
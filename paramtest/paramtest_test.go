@@ -0,0 +1,134 @@
+package paramtest
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oceanicdev/chi-param"
+)
+
+// recorder captures failures so assertions can be checked without failing the test
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.failed = true
+}
+
+func TestNewRequest(t *testing.T) {
+	req := NewRequest("POST", "/users/{id}").
+		Path("id", "42").
+		Query("tag", "a", "b").
+		Header("X-Request-Id", "abc").
+		Body(strings.NewReader("hello")).
+		Build()
+
+	id, err := param.Int(req, "id")
+	AssertNoError(t, err)
+	if id != 42 {
+		t.Fatalf("want %d, got %d", 42, id)
+	}
+
+	tags, err := param.QueryStringArray(req, "tag")
+	AssertNoError(t, err)
+	if !reflect.DeepEqual([]string{"a", "b"}, tags) {
+		t.Fatalf("want %v, got %v", []string{"a", "b"}, tags)
+	}
+
+	if req.Method != "POST" {
+		t.Fatalf("want %s, got %s", "POST", req.Method)
+	}
+	if req.URL.Path != "/users/42" {
+		t.Fatalf("want %s, got %s", "/users/42", req.URL.Path)
+	}
+	if got := req.Header.Get("X-Request-Id"); got != "abc" {
+		t.Fatalf("want %s, got %s", "abc", got)
+	}
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != "hello" {
+		t.Fatalf("want %s, got %s", "hello", body)
+	}
+}
+
+func TestNewRequestMissingPath(t *testing.T) {
+	req := NewRequest("GET", "/users/{id}").Build()
+
+	if req.URL.Path != "/users/{id}" {
+		t.Fatalf("want %s, got %s", "/users/{id}", req.URL.Path)
+	}
+
+	_, err := param.String(req, "id")
+	AssertInvalidParam(t, err)
+}
+
+func TestNewRequestMissingPathErr(t *testing.T) {
+	b := NewRequest("GET", "/users/{id}")
+	b.Build()
+	AssertErrorIs(t, b.Err(), param.ErrMissingParam)
+
+	b.Path("id", "42").Build()
+	AssertNoError(t, b.Err())
+}
+
+func TestNewRequestEscapedPath(t *testing.T) {
+	b := NewRequest("GET", "/files/{name}").Path("name", "a b/c")
+	req := b.Build()
+	AssertNoError(t, b.Err())
+
+	if req.URL.Path != "/files/a b/c" || req.URL.EscapedPath() != "/files/a%20b%2Fc" {
+		t.Fatalf("unexpected path %q, escaped %q", req.URL.Path, req.URL.EscapedPath())
+	}
+	if req.RequestURI != "/files/a%20b%2Fc" {
+		t.Fatalf("want %s, got %s", "/files/a%20b%2Fc", req.RequestURI)
+	}
+
+	name, err := param.String(req, "name")
+	AssertNoError(t, err)
+	if name != "a b/c" {
+		t.Fatalf("want %s, got %s", "a b/c", name)
+	}
+}
+
+func TestAssertions(t *testing.T) {
+	req := NewRequest("GET", "/{id}").Path("id", "ten").Query("age", "300").Build()
+
+	_, err := param.Int(req, "id")
+	AssertSyntaxError(t, err)
+
+	_, err = param.QueryInt8(req, "age")
+	AssertRangeError(t, err)
+
+	_, err = param.QueryInt(req, "missing")
+	AssertInvalidParam(t, err)
+
+	_, err = param.Time(req, "id")
+	AssertSyntaxError(t, err)
+
+	_, err = param.BuildPath("/{id}", nil)
+	AssertMissingParam(t, err)
+}
+
+func TestAssertionsFail(t *testing.T) {
+	tests := []func(testing.TB){
+		func(tb testing.TB) { AssertNoError(tb, errors.New("boom")) },
+		func(tb testing.TB) { AssertInvalidParam(tb, nil) },
+		func(tb testing.TB) { AssertMissingParam(tb, param.ErrInvalidParam) },
+		func(tb testing.TB) { AssertSyntaxError(tb, nil) },
+		func(tb testing.TB) { AssertRangeError(tb, errors.New("boom")) },
+	}
+
+	for index, assert := range tests {
+		r := &recorder{TB: t}
+		assert(r)
+		if !r.failed {
+			t.Fatalf("assertion %d did not fail", index)
+		}
+	}
+}
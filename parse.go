@@ -0,0 +1,113 @@
+package param
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// pathValue returns a path parameter and reports whether the route defines it
+func pathValue(r *http.Request, key string) (string, bool) {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return "", false
+	}
+	for k := len(rctx.URLParams.Keys) - 1; k >= 0; k-- {
+		if rctx.URLParams.Keys[k] == key {
+			return rctx.URLParams.Values[k], true
+		}
+	}
+	return "", false
+}
+
+// queryValue returns the first query parameter and reports whether it is present
+func queryValue(r *http.Request, key string) (string, bool) {
+	values, ok := r.URL.Query()[key]
+	if !ok || len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// unplus restores + signs stripped out during url parse stage
+func unplus(value string) string {
+	if strings.Contains(value, " ") {
+		value = strings.ReplaceAll(value, " ", "+")
+	}
+	return value
+}
+
+func parseString(value string) (string, error) {
+	return value, nil
+}
+
+func parseInt8(value string) (int8, error) {
+	v, err := strconv.ParseInt(value, 10, 8)
+	return int8(v), err
+}
+
+func parseInt16(value string) (int16, error) {
+	v, err := strconv.ParseInt(value, 10, 16)
+	return int16(v), err
+}
+
+func parseInt32(value string) (int32, error) {
+	v, err := strconv.ParseInt(value, 10, 32)
+	return int32(v), err
+}
+
+func parseInt64(value string) (int64, error) {
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func parseUint(value string) (uint, error) {
+	v, err := strconv.ParseUint(value, 10, 32)
+	return uint(v), err
+}
+
+func parseUint8(value string) (uint8, error) {
+	v, err := strconv.ParseUint(value, 10, 8)
+	return uint8(v), err
+}
+
+func parseUint16(value string) (uint16, error) {
+	v, err := strconv.ParseUint(value, 10, 16)
+	return uint16(v), err
+}
+
+func parseUint32(value string) (uint32, error) {
+	v, err := strconv.ParseUint(value, 10, 32)
+	return uint32(v), err
+}
+
+func parseUint64(value string) (uint64, error) {
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func parseFloat32(value string) (float32, error) {
+	v, err := strconv.ParseFloat(value, 32)
+	return float32(v), err
+}
+
+func parseFloat64(value string) (float64, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+func parseQueryFloat32(value string) (float32, error) {
+	return parseFloat32(unplus(value))
+}
+
+func parseQueryFloat64(value string) (float64, error) {
+	return parseFloat64(unplus(value))
+}
+
+func parseTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func parseQueryTime(value string) (time.Time, error) {
+	return parseTime(unplus(value))
+}
@@ -0,0 +1,157 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrMissingParam is an error for a route parameter without a value
+	ErrMissingParam = errors.New("Missing parameter")
+	// ErrUnknownParam is an error for a value without a route parameter
+	ErrUnknownParam = errors.New("Unknown parameter")
+	// ErrInvalidPattern is an error for a malformed route pattern
+	ErrInvalidPattern = errors.New("Invalid route pattern")
+)
+
+// BuildPath fills a chi route pattern such as "/orgs/{orgID}/users/{id:[0-9]+}"
+// with the given values. Values are formatted the same way as the query
+// builder does, escaped as path segments and checked against inline regexp
+// constraints. The wildcard "*" keeps its slashes. Every route parameter
+// must have a value and every value must belong to a route parameter.
+func BuildPath(pattern string, values map[string]interface{}) (string, error) {
+	var (
+		out     strings.Builder
+		used    = make(map[string]bool, len(values))
+		missing []string
+	)
+
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			end, err := closingBrace(pattern, i)
+			if err != nil {
+				return "", err
+			}
+			key, rexpat, _ := strings.Cut(pattern[i+1:end], ":")
+			if len(key) == 0 {
+				return "", fmt.Errorf("%w: empty parameter name in %q", ErrInvalidPattern, pattern)
+			}
+			i = end
+
+			value, ok := values[key]
+			if !ok {
+				missing = append(missing, key)
+				continue
+			}
+			used[key] = true
+
+			s, err := formatPathValue(value)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", key, err)
+			}
+			if len(s) == 0 {
+				return "", fmt.Errorf("%w: %s is empty", ErrInvalidParam, key)
+			}
+			if len(rexpat) > 0 {
+				if err := matchConstraint(key, rexpat, s); err != nil {
+					return "", err
+				}
+			}
+			out.WriteString(url.PathEscape(s))
+		case '*':
+			if i != len(pattern)-1 {
+				return "", fmt.Errorf("%w: wildcard must be the last character in %q", ErrInvalidPattern, pattern)
+			}
+			value, ok := values["*"]
+			if !ok {
+				missing = append(missing, "*")
+				continue
+			}
+			used["*"] = true
+
+			s, err := formatPathValue(value)
+			if err != nil {
+				return "", fmt.Errorf("*: %w", err)
+			}
+			segments := strings.Split(s, "/")
+			for index, segment := range segments {
+				segments[index] = url.PathEscape(segment)
+			}
+			out.WriteString(strings.Join(segments, "/"))
+		default:
+			out.WriteByte(pattern[i])
+		}
+	}
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%w: %s", ErrMissingParam, strings.Join(missing, ", "))
+	}
+	if len(used) < len(values) {
+		var extra []string
+		for key := range values {
+			if !used[key] {
+				extra = append(extra, key)
+			}
+		}
+		sort.Strings(extra)
+		return "", fmt.Errorf("%w: %s", ErrUnknownParam, strings.Join(extra, ", "))
+	}
+	return out.String(), nil
+}
+
+// closingBrace returns the index of the brace closing the one at start,
+// regexp constraints may contain nested braces such as {id:[0-9]{4}}
+func closingBrace(pattern string, start int) (int, error) {
+	depth := 0
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: unclosed parameter in %q", ErrInvalidPattern, pattern)
+}
+
+// matchConstraint checks a value against a regexp constraint the way chi
+// anchors it when matching routes
+func matchConstraint(key, rexpat, value string) error {
+	if rexpat[0] != '^' {
+		rexpat = "^" + rexpat
+	}
+	if rexpat[len(rexpat)-1] != '$' {
+		rexpat += "$"
+	}
+	rx, err := regexp.Compile(rexpat)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidPattern, key, err)
+	}
+	if !rx.MatchString(value) {
+		return fmt.Errorf("%w: %s value %q does not match %s", ErrInvalidParam, key, value, rexpat)
+	}
+	return nil
+}
+
+func formatPathValue(value interface{}) (string, error) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", nil
+	}
+	return encodeScalar(v)
+}
@@ -0,0 +1,88 @@
+package param
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestBuildPath(t *testing.T) {
+	id := int64(42)
+	tests := []struct {
+		pattern string
+		values  map[string]interface{}
+		want    string
+	}{
+		{"/orgs/{orgID}/users/{id:[0-9]+}", map[string]interface{}{"orgID": "acme inc", "id": &id}, "/orgs/acme%20inc/users/42"},
+		{"/files/{name}", map[string]interface{}{"name": "a/b?c"}, "/files/a%2Fb%3Fc"},
+		{"/years/{year:[0-9]{4}}", map[string]interface{}{"year": uint16(2024)}, "/years/2024"},
+		{"/static/*", map[string]interface{}{"*": "css/site main.css"}, "/static/css/site%20main.css"},
+		{"/flags/{on}-{ratio}", map[string]interface{}{"on": true, "ratio": 0.5}, "/flags/true-0.5"},
+	}
+
+	for _, tt := range tests {
+		got, err := BuildPath(tt.pattern, tt.values)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.pattern, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("%s: want %q, got %q", tt.pattern, tt.want, got)
+		}
+	}
+}
+
+func TestBuildPathRoundTrip(t *testing.T) {
+	pattern := "/orgs/{orgID}/users/{id:[0-9]+}"
+	path, err := BuildPath(pattern, map[string]interface{}{"orgID": "a/b c", "id": 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		orgID string
+		id    int
+	)
+	r := chi.NewRouter()
+	r.Get(pattern, func(w http.ResponseWriter, r *http.Request) {
+		orgID, _ = String(r, "orgID")
+		id, _ = Int(r, "id")
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+
+	if id != 7 {
+		t.Fatalf("want %d, got %d", 7, id)
+	}
+
+	// chi routes on the raw path, so escaped segments stay escaped
+	if orgID != "a%2Fb%20c" {
+		t.Fatalf("want %q, got %q", "a%2Fb%20c", orgID)
+	}
+}
+
+func TestBuildPathErr(t *testing.T) {
+	tests := []struct {
+		pattern string
+		values  map[string]interface{}
+		want    error
+	}{
+		{"/users/{id}", map[string]interface{}{}, ErrMissingParam},
+		{"/users/{id}", map[string]interface{}{"id": 1, "name": "x"}, ErrUnknownParam},
+		{"/users/{id:[0-9]+}", map[string]interface{}{"id": "abc"}, ErrInvalidParam},
+		{"/users/{id}", map[string]interface{}{"id": ""}, ErrInvalidParam},
+		{"/users/{id", map[string]interface{}{"id": 1}, ErrInvalidPattern},
+		{"/users/{:[0-9]+}", map[string]interface{}{}, ErrInvalidPattern},
+		{"/users/{id:[0-9}", map[string]interface{}{"id": 1}, ErrInvalidPattern},
+		{"/users/{id}", map[string]interface{}{"id": []int{1}}, ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		_, err := BuildPath(tt.pattern, tt.values)
+		if !errors.Is(err, tt.want) {
+			t.Fatalf("%s %v: want %v, got %v", tt.pattern, tt.values, tt.want, err)
+		}
+	}
+}
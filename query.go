@@ -0,0 +1,403 @@
+package param
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedType is an error for a value that can not be encoded as a parameter
+var ErrUnsupportedType = errors.New("Unsupported parameter type")
+
+// ErrAmbiguousNull is an error for a valid Null whose value reads back as null
+var ErrAmbiguousNull = errors.New("Value reads back as null")
+
+// Query builds query strings with the same typing and array conventions
+// the Query* getters parse, so every value it writes can be read back
+type Query struct {
+	values url.Values
+}
+
+// NewQuery returns an empty query builder
+func NewQuery() *Query {
+	return &Query{values: url.Values{}}
+}
+
+// Values returns the built query parameters
+func (q *Query) Values() url.Values {
+	return q.values
+}
+
+// Encode returns the built query parameters in URL encoded form
+func (q *Query) Encode() string {
+	return q.values.Encode()
+}
+
+func (q *Query) set(key string, values []string) *Query {
+	q.values.Del(key)
+	for _, value := range values {
+		q.values.Add(key, value)
+	}
+	return q
+}
+
+// String sets a query parameter with string type
+func (q *Query) String(key string, value string) *Query {
+	return q.set(key, []string{value})
+}
+
+// Int sets a query parameter with int type
+func (q *Query) Int(key string, value int) *Query {
+	return q.Int64(key, int64(value))
+}
+
+// Int8 sets a query parameter with int8 type
+func (q *Query) Int8(key string, value int8) *Query {
+	return q.Int64(key, int64(value))
+}
+
+// Int16 sets a query parameter with int16 type
+func (q *Query) Int16(key string, value int16) *Query {
+	return q.Int64(key, int64(value))
+}
+
+// Int32 sets a query parameter with int32 type
+func (q *Query) Int32(key string, value int32) *Query {
+	return q.Int64(key, int64(value))
+}
+
+// Int64 sets a query parameter with int64 type
+func (q *Query) Int64(key string, value int64) *Query {
+	return q.set(key, []string{strconv.FormatInt(value, 10)})
+}
+
+// Uint sets a query parameter with uint type. QueryUint reads 32 bits, so
+// larger values need Uint64 to be read back.
+func (q *Query) Uint(key string, value uint) *Query {
+	return q.Uint64(key, uint64(value))
+}
+
+// Uint8 sets a query parameter with uint8 type
+func (q *Query) Uint8(key string, value uint8) *Query {
+	return q.Uint64(key, uint64(value))
+}
+
+// Uint16 sets a query parameter with uint16 type
+func (q *Query) Uint16(key string, value uint16) *Query {
+	return q.Uint64(key, uint64(value))
+}
+
+// Uint32 sets a query parameter with uint32 type
+func (q *Query) Uint32(key string, value uint32) *Query {
+	return q.Uint64(key, uint64(value))
+}
+
+// Uint64 sets a query parameter with uint64 type
+func (q *Query) Uint64(key string, value uint64) *Query {
+	return q.set(key, []string{strconv.FormatUint(value, 10)})
+}
+
+// Bool sets a query parameter with boolean type
+func (q *Query) Bool(key string, value bool) *Query {
+	return q.set(key, []string{strconv.FormatBool(value)})
+}
+
+// Float32 sets a query parameter with float32 type
+func (q *Query) Float32(key string, value float32) *Query {
+	return q.set(key, []string{formatFloat(float64(value), 32)})
+}
+
+// Float64 sets a query parameter with float64 type
+func (q *Query) Float64(key string, value float64) *Query {
+	return q.set(key, []string{formatFloat(value, 64)})
+}
+
+// Time sets a query parameter with time type, formatted as RFC 3339
+func (q *Query) Time(key string, value time.Time) *Query {
+	return q.set(key, []string{formatTime(value)})
+}
+
+// StringArray sets a slice of query parameters with string type
+func (q *Query) StringArray(key string, values []string) *Query {
+	return q.set(key, values)
+}
+
+// IntArray sets a slice of query parameters with int type
+func (q *Query) IntArray(key string, values []int) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.Itoa(value)
+	}
+	return q.set(key, out)
+}
+
+// Int8Array sets a slice of query parameters with int8 type
+func (q *Query) Int8Array(key string, values []int8) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatInt(int64(value), 10)
+	}
+	return q.set(key, out)
+}
+
+// Int16Array sets a slice of query parameters with int16 type
+func (q *Query) Int16Array(key string, values []int16) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatInt(int64(value), 10)
+	}
+	return q.set(key, out)
+}
+
+// Int32Array sets a slice of query parameters with int32 type
+func (q *Query) Int32Array(key string, values []int32) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatInt(int64(value), 10)
+	}
+	return q.set(key, out)
+}
+
+// Int64Array sets a slice of query parameters with int64 type
+func (q *Query) Int64Array(key string, values []int64) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatInt(value, 10)
+	}
+	return q.set(key, out)
+}
+
+// UintArray sets a slice of query parameters with uint type, values
+// larger than 32 bits need Uint64Array to be read back
+func (q *Query) UintArray(key string, values []uint) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatUint(uint64(value), 10)
+	}
+	return q.set(key, out)
+}
+
+// Uint8Array sets a slice of query parameters with uint8 type
+func (q *Query) Uint8Array(key string, values []uint8) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatUint(uint64(value), 10)
+	}
+	return q.set(key, out)
+}
+
+// Uint16Array sets a slice of query parameters with uint16 type
+func (q *Query) Uint16Array(key string, values []uint16) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatUint(uint64(value), 10)
+	}
+	return q.set(key, out)
+}
+
+// Uint32Array sets a slice of query parameters with uint32 type
+func (q *Query) Uint32Array(key string, values []uint32) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatUint(uint64(value), 10)
+	}
+	return q.set(key, out)
+}
+
+// Uint64Array sets a slice of query parameters with uint64 type
+func (q *Query) Uint64Array(key string, values []uint64) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatUint(value, 10)
+	}
+	return q.set(key, out)
+}
+
+// BoolArray sets a slice of query parameters with boolean type
+func (q *Query) BoolArray(key string, values []bool) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = strconv.FormatBool(value)
+	}
+	return q.set(key, out)
+}
+
+// Float32Array sets a slice of query parameters with float32 type
+func (q *Query) Float32Array(key string, values []float32) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = formatFloat(float64(value), 32)
+	}
+	return q.set(key, out)
+}
+
+// Float64Array sets a slice of query parameters with float64 type
+func (q *Query) Float64Array(key string, values []float64) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = formatFloat(value, 64)
+	}
+	return q.set(key, out)
+}
+
+// TimeArray sets a slice of query parameters with time type, formatted as RFC 3339
+func (q *Query) TimeArray(key string, values []time.Time) *Query {
+	out := make([]string, len(values))
+	for index, value := range values {
+		out[index] = formatTime(value)
+	}
+	return q.set(key, out)
+}
+
+func formatFloat(value float64, bitSize int) string {
+	return strconv.FormatFloat(value, 'g', -1, bitSize)
+}
+
+func formatTime(value time.Time) string {
+	return value.Format(time.RFC3339Nano)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// EncodeQuery encodes a struct into query parameters. Fields are named by
+// the "param" tag, a "-" name skips the field and the "omitempty" option
+// skips zero values. Slices are encoded as repeated keys, nil pointers and
+// unset Null values are omitted and invalid Null values are encoded as "null".
+// A valid Null of "" or "null" would read back as null and fails with
+// ErrAmbiguousNull, uint values above 32 bits fail with strconv.ErrRange.
+func EncodeQuery(v interface{}) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return url.Values{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, rv.Type())
+	}
+
+	out := url.Values{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, omitEmpty := field.Name, false
+		if tag, ok := field.Tag.Lookup("param"); ok {
+			var opts string
+			name, opts, _ = strings.Cut(tag, ",")
+			if name == "-" {
+				continue
+			}
+			if len(name) == 0 {
+				name = field.Name
+			}
+			for _, opt := range strings.Split(opts, ",") {
+				omitEmpty = omitEmpty || opt == "omitempty"
+			}
+		}
+
+		fv := rv.Field(i)
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+		values, err := encodeValue(fv)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		for _, value := range values {
+			out.Add(name, value)
+		}
+	}
+	return out, nil
+}
+
+func encodeValue(v reflect.Value) ([]string, error) {
+	// a nil *Null has the method set of Null but no value to call it on
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil, nil
+	}
+	if n, ok := v.Interface().(nullable); ok {
+		value, valid, set := n.nullState()
+		switch {
+		case !set:
+			return nil, nil
+		case !valid:
+			return []string{"null"}, nil
+		}
+		values, err := encodeValue(reflect.ValueOf(value))
+		if err == nil && len(values) == 1 && isNull(values[0]) {
+			return nil, fmt.Errorf("%w: %q", ErrAmbiguousNull, values[0])
+		}
+		return values, err
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return encodeValue(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if _, ok := v.Interface().(encoding.TextMarshaler); ok {
+			break
+		}
+		var out []string
+		for i := 0; i < v.Len(); i++ {
+			values, err := encodeValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, values...)
+		}
+		return out, nil
+	}
+
+	value, err := encodeScalar(v)
+	if err != nil {
+		return nil, err
+	}
+	return []string{value}, nil
+}
+
+func encodeScalar(v reflect.Value) (string, error) {
+	if v.Type() == timeType {
+		return formatTime(v.Interface().(time.Time)), nil
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint:
+		// the uint getters read 32 bits whatever the platform
+		if v.Uint() > math.MaxUint32 {
+			return "", numError("FormatUint", strconv.FormatUint(v.Uint(), 10), strconv.ErrRange)
+		}
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Float32:
+		return formatFloat(v.Float(), 32), nil
+	case reflect.Float64:
+		return formatFloat(v.Float(), 64), nil
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+}
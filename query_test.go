@@ -0,0 +1,168 @@
+package param
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	since := time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("", 2*60*60))
+	ids := []int64{1, math.MaxInt64}
+	floats := []float64{1e21, -0.5, math.MaxFloat64}
+
+	q := NewQuery().
+		Int("page", 2).
+		Int64Array("id", ids).
+		Float64Array("score", floats).
+		Time("since", since).
+		String("name", "a+b c")
+	req := newQueryRequest(t, q.Encode())
+
+	page, err := QueryInt(req, "page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page != 2 {
+		t.Fatalf("want %d, got %d", 2, page)
+	}
+
+	gotIDs, err := QueryInt64Array(req, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, gotIDs) {
+		t.Fatalf("want %v, got %v", ids, gotIDs)
+	}
+
+	gotFloats, err := QueryFloat64Array(req, "score")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(floats, gotFloats) {
+		t.Fatalf("want %v, got %v", floats, gotFloats)
+	}
+
+	gotSince, err := QueryTime(req, "since")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !since.Equal(gotSince) {
+		t.Fatalf("want %v, got %v", since, gotSince)
+	}
+
+	name, err := QueryString(req, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "a+b c" {
+		t.Fatalf("want %q, got %q", "a+b c", name)
+	}
+}
+
+func TestQueryBuilderReplaces(t *testing.T) {
+	q := NewQuery().IntArray("id", []int{1, 2}).Int("id", 3)
+
+	want := url.Values{"id": {"3"}}
+	if !reflect.DeepEqual(want, q.Values()) {
+		t.Fatalf("want %v, got %v", want, q.Values())
+	}
+}
+
+func TestQueryTimeUnescapedOffset(t *testing.T) {
+	req := newQueryRequest(t, "since=2024-01-02T03:04:05+02:00")
+
+	got, err := QueryTime(req, "since")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Date(2024, 1, 2, 1, 4, 5, 0, time.UTC)
+	if !want.Equal(got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestEncodeQuery(t *testing.T) {
+	type filter struct {
+		Page     int       `param:"page"`
+		Tags     []string  `param:"tag"`
+		Owner    *string   `param:"owner"`
+		Since    time.Time `param:"since,omitempty"`
+		Archived Null[bool]
+		Deleted  Null[bool] `param:"deleted"`
+		Ratio    float32    `param:"ratio"`
+		Secret   string     `param:"-"`
+		internal int
+	}
+
+	got, err := EncodeQuery(&filter{
+		Page:     3,
+		Tags:     []string{"a", "b"},
+		Archived: Null[bool]{Set: true},
+		Ratio:    0.1,
+		Secret:   "hidden",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := url.Values{
+		"page":     {"3"},
+		"tag":      {"a", "b"},
+		"Archived": {"null"},
+		"ratio":    {"0.1"},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestEncodeQueryNilNull(t *testing.T) {
+	type filter struct {
+		Active *Null[bool] `param:"active"`
+		Draft  *Null[bool] `param:"draft"`
+		Limit  int         `param:"limit,string,omitempty"`
+	}
+
+	got, err := EncodeQuery(filter{Draft: &Null[bool]{V: true, Valid: true, Set: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := url.Values{"draft": {"true"}}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestEncodeQueryErr(t *testing.T) {
+	_, err := EncodeQuery(42)
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("want ErrUnsupportedType, got %v", err)
+	}
+
+	_, err = EncodeQuery(struct{ M map[string]int }{})
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("want ErrUnsupportedType, got %v", err)
+	}
+
+	for _, value := range []string{"", "null"} {
+		_, err = EncodeQuery(struct{ S Null[string] }{Null[string]{V: value, Valid: true, Set: true}})
+		if !errors.Is(err, ErrAmbiguousNull) {
+			t.Fatalf("%q: want ErrAmbiguousNull, got %v", value, err)
+		}
+	}
+
+	if strconv.IntSize == 64 {
+		wide := uint64(1) << 40
+		_, err = EncodeQuery(struct{ N uint }{uint(wide)})
+		if !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("want strconv.ErrRange, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// DefaultMaxSetSize is the number of members a set may have when no limit is given
+const DefaultMaxSetSize = 10000
+
+var (
+	// ErrInvalidRange is an error for a range or set whose bounds are out
+	// of order, missing or not a number
+	ErrInvalidRange = errors.New("Invalid range")
+	// ErrSetTooLarge is an error for a set with more members than allowed
+	ErrSetTooLarge = errors.New("Set is too large")
+)
+
+// Integer is a constraint for the integer types the package parses
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Number is a constraint for the numeric types the package parses
+type Number interface {
+	Integer | ~float32 | ~float64
+}
+
+// parseNumber parses a number with the same base and bit size checks as
+// the getter of its type
+func parseNumber[T Number](value string) (T, error) {
+	t := reflect.TypeOf(T(0))
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(value, 10, t.Bits())
+		return T(v), err
+	case reflect.Uint:
+		// Uint is parsed as 32 bits wide, see Uint
+		v, err := strconv.ParseUint(value, 10, 32)
+		return T(v), err
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(value, 10, t.Bits())
+		return T(v), err
+	}
+	v, err := strconv.ParseFloat(value, t.Bits())
+	return T(v), err
+}
+
+// Range is an inclusive range of numbers, a bound that is not set leaves
+// the range open on that side
+type Range[T Number] struct {
+	Min    T
+	Max    T
+	HasMin bool
+	HasMax bool
+}
+
+// Contains reports whether v is within the range
+func (r Range[T]) Contains(v T) bool {
+	return (!r.HasMin || v >= r.Min) && (!r.HasMax || v <= r.Max)
+}
+
+// String returns the range in "min..max" form
+func (r Range[T]) String() string {
+	var b strings.Builder
+	if r.HasMin {
+		fmt.Fprint(&b, r.Min)
+	}
+	b.WriteString("..")
+	if r.HasMax {
+		fmt.Fprint(&b, r.Max)
+	}
+	return b.String()
+}
+
+// ParseRange parses a range such as "10..50", "10.." or "..50". A single
+// number is a range holding only that number.
+func ParseRange[T Number](value string) (Range[T], error) {
+	var out Range[T]
+	lo, hi, ok := strings.Cut(value, "..")
+	if !ok {
+		v, err := parseBound[T](value)
+		if err != nil {
+			return Range[T]{}, err
+		}
+		return Range[T]{Min: v, Max: v, HasMin: true, HasMax: true}, nil
+	}
+	if len(lo) == 0 && len(hi) == 0 {
+		return Range[T]{}, fmt.Errorf("%w: no bounds", ErrInvalidRange)
+	}
+	if len(lo) > 0 {
+		v, err := parseBound[T](lo)
+		if err != nil {
+			return Range[T]{}, err
+		}
+		out.Min, out.HasMin = v, true
+	}
+	if len(hi) > 0 {
+		v, err := parseBound[T](hi)
+		if err != nil {
+			return Range[T]{}, err
+		}
+		out.Max, out.HasMax = v, true
+	}
+	if out.HasMin && out.HasMax && out.Min > out.Max {
+		return Range[T]{}, fmt.Errorf("%w: start %v is after end %v", ErrInvalidRange, out.Min, out.Max)
+	}
+	return out, nil
+}
+
+// parseBound parses a range bound, NaN is rejected since nothing compares
+// to it
+func parseBound[T Number](value string) (T, error) {
+	v, err := parseNumber[T](value)
+	if err != nil {
+		return 0, err
+	}
+	if v != v {
+		return 0, fmt.Errorf("%w: NaN bound", ErrInvalidRange)
+	}
+	return v, nil
+}
+
+// PathRange returns a path parameter as a range
+func PathRange[T Number](r *http.Request, key string) (Range[T], error) {
+	value := chi.URLParam(r, key)
+	out, err := ParseRange[T](value)
+	if err != nil {
+		return Range[T]{}, wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// QueryRange returns a query parameter as a range
+func QueryRange[T Number](r *http.Request, key string) (Range[T], error) {
+	value, err := QueryString(r, key)
+	if err != nil {
+		return Range[T]{}, err
+	}
+	out, err := ParseRange[T](unplus(value))
+	if err != nil {
+		return Range[T]{}, wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// Interval is an inclusive interval of a set
+type Interval[T Integer] struct {
+	Lo T
+	Hi T
+}
+
+// IntSet is a set of integers kept in compact form, as sorted intervals
+// that neither overlap nor touch
+type IntSet[T Integer] struct {
+	Intervals []Interval[T]
+}
+
+// Contains reports whether v is a member of the set
+func (s IntSet[T]) Contains(v T) bool {
+	i := sort.Search(len(s.Intervals), func(i int) bool {
+		return s.Intervals[i].Hi >= v
+	})
+	return i < len(s.Intervals) && s.Intervals[i].Lo <= v
+}
+
+// Len returns the number of members of the set
+func (s IntSet[T]) Len() uint64 {
+	var n uint64
+	for _, in := range s.Intervals {
+		n += span(in.Lo, in.Hi)
+	}
+	return n
+}
+
+// Values returns the members of the set in ascending order
+func (s IntSet[T]) Values() []T {
+	out := make([]T, 0, s.Len())
+	for _, in := range s.Intervals {
+		for v := in.Lo; ; v++ {
+			out = append(out, v)
+			if v == in.Hi {
+				break
+			}
+		}
+	}
+	return out
+}
+
+// String returns the set in "1-10,15" form
+func (s IntSet[T]) String() string {
+	out := make([]string, len(s.Intervals))
+	for index, in := range s.Intervals {
+		if in.Lo == in.Hi {
+			out[index] = fmt.Sprint(in.Lo)
+			continue
+		}
+		out[index] = fmt.Sprintf("%v-%v", in.Lo, in.Hi)
+	}
+	return strings.Join(out, ",")
+}
+
+// span returns the number of integers in [lo, hi] without overflowing,
+// saturating at the largest uint64
+func span[T Integer](lo, hi T) uint64 {
+	n := uint64(hi) - uint64(lo)
+	if n == ^uint64(0) {
+		return n
+	}
+	return n + 1
+}
+
+// ParseIntSet parses a set such as "1-10,15,20-22". The set may hold at
+// most maxSize members, a zero maxSize uses DefaultMaxSetSize.
+func ParseIntSet[T Integer](value string, maxSize int) (IntSet[T], error) {
+	return parseIntSet[T](strings.Split(value, ","), maxSize)
+}
+
+func parseIntSet[T Integer](items []string, maxSize int) (IntSet[T], error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSetSize
+	}
+
+	var (
+		intervals []Interval[T]
+		total     uint64
+	)
+	for _, item := range items {
+		if len(item) == 0 {
+			return IntSet[T]{}, fmt.Errorf("%w: empty set item", ErrInvalidRange)
+		}
+		// a leading minus belongs to the number, the next one separates the bounds
+		lo, hi := item, item
+		if i := strings.IndexByte(item[1:], '-'); i >= 0 {
+			lo, hi = item[:i+1], item[i+2:]
+		}
+		l, err := parseNumber[T](lo)
+		if err != nil {
+			return IntSet[T]{}, err
+		}
+		h, err := parseNumber[T](hi)
+		if err != nil {
+			return IntSet[T]{}, err
+		}
+		if l > h {
+			return IntSet[T]{}, fmt.Errorf("%w: set item start %v is after end %v", ErrInvalidRange, l, h)
+		}
+		// checked before the intervals are merged so overlapping items can
+		// not be used to hide an unbounded amount of work either
+		total += span(l, h)
+		if total > uint64(maxSize) || total < span(l, h) {
+			return IntSet[T]{}, fmt.Errorf("%w: more than %d members", ErrSetTooLarge, maxSize)
+		}
+		intervals = append(intervals, Interval[T]{Lo: l, Hi: h})
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Lo < intervals[j].Lo
+	})
+	var out []Interval[T]
+	for _, in := range intervals {
+		if n := len(out); n > 0 && (in.Lo <= out[n-1].Hi || in.Lo-1 == out[n-1].Hi) {
+			if in.Hi > out[n-1].Hi {
+				out[n-1].Hi = in.Hi
+			}
+			continue
+		}
+		out = append(out, in)
+	}
+	return IntSet[T]{Intervals: out}, nil
+}
+
+// PathIntSet returns a path parameter as a set of integers
+func PathIntSet[T Integer](r *http.Request, key string, maxSize int) (IntSet[T], error) {
+	value := chi.URLParam(r, key)
+	out, err := ParseIntSet[T](value, maxSize)
+	if err != nil {
+		return IntSet[T]{}, wrapParam(key, value, err)
+	}
+	return out, nil
+}
+
+// QueryIntSet returns query parameters as a set of integers, items may be
+// comma separated and repeated across keys
+func QueryIntSet[T Integer](r *http.Request, key string, maxSize int) (IntSet[T], error) {
+	values, err := QueryStringArray(r, key)
+	if err != nil {
+		return IntSet[T]{}, err
+	}
+	var items []string
+	for _, value := range values {
+		items = append(items, strings.Split(value, ",")...)
+	}
+	out, err := parseIntSet[T](items, maxSize)
+	if err != nil {
+		return IntSet[T]{}, wrapParam(key, strings.Join(values, ","), err)
+	}
+	return out, nil
+}
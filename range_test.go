@@ -0,0 +1,197 @@
+package param
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Range[int64]
+	}{
+		{"10..50", Range[int64]{Min: 10, Max: 50, HasMin: true, HasMax: true}},
+		{"10..", Range[int64]{Min: 10, HasMin: true}},
+		{"..50", Range[int64]{Max: 50, HasMax: true}},
+		{"-5..-1", Range[int64]{Min: -5, Max: -1, HasMin: true, HasMax: true}},
+		{"7", Range[int64]{Min: 7, Max: 7, HasMin: true, HasMax: true}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRange[int64](tt.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("%s: want %+v, got %+v", tt.value, tt.want, got)
+		}
+
+		if got.String() != tt.value && tt.value != "7" {
+			t.Fatalf("want %s, got %s", tt.value, got.String())
+		}
+	}
+}
+
+func TestParseRangeErr(t *testing.T) {
+	for _, value := range []string{"..", "50..10", "a..b", "1...2", ""} {
+		if _, err := ParseRange[int](value); err == nil {
+			t.Fatalf("%q: expected error", value)
+		}
+	}
+
+	_, err := ParseRange[int8]("0..300")
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("want strconv.ErrRange, got %v", err)
+	}
+
+	_, err = ParseRange[uint]("0..-1")
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("want strconv.ErrSyntax, got %v", err)
+	}
+
+	for _, value := range []string{"..", "50..10", "NaN..1", "0..NaN", "NaN"} {
+		if _, err := ParseRange[float64](value); !errors.Is(err, ErrInvalidRange) {
+			t.Fatalf("%q: want ErrInvalidRange, got %v", value, err)
+		}
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	r := Range[float64]{Min: 10, HasMin: true}
+
+	if !r.Contains(10) || !r.Contains(math.MaxFloat64) || r.Contains(9.99) {
+		t.Fatalf("unexpected containment for %s", r)
+	}
+}
+
+func TestPathRange(t *testing.T) {
+	req, key := newParamRequest(t, "10..50")
+
+	got, err := PathRange[uint16](req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Range[uint16]{Min: 10, Max: 50, HasMin: true, HasMax: true}
+	if got != want {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+
+	req, key = newParamRequest(t, "50..10")
+	_, err = PathRange[uint16](req, key)
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestQueryRange(t *testing.T) {
+	req := newQueryRequest(t, "price=1e+1..5e+1")
+
+	got, err := QueryRange[float32](req, "price")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Range[float32]{Min: 10, Max: 50, HasMin: true, HasMax: true}
+	if got != want {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+
+	_, err = QueryRange[float32](req, "size")
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestParseIntSet(t *testing.T) {
+	got, err := ParseIntSet[int]("20-22,1-10,15,11,-3--1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Interval[int]{{-3, -1}, {1, 11}, {15, 15}, {20, 22}}
+	if !reflect.DeepEqual(want, got.Intervals) {
+		t.Fatalf("want %v, got %v", want, got.Intervals)
+	}
+
+	if got.Len() != 18 {
+		t.Fatalf("want 18 members, got %d", got.Len())
+	}
+
+	if !got.Contains(15) || got.Contains(12) || got.Contains(0) || !got.Contains(-2) {
+		t.Fatalf("unexpected membership for %s", got)
+	}
+
+	values := got.Values()
+	if len(values) != 18 || values[0] != -3 || values[17] != 22 {
+		t.Fatalf("unexpected values %v", values)
+	}
+
+	if got.String() != "-3--1,1-11,15,20-22" {
+		t.Fatalf("unexpected string %s", got)
+	}
+}
+
+func TestParseIntSetErr(t *testing.T) {
+	for _, value := range []string{"", "1,,2", "5-1", "a-b", "1-2-3"} {
+		if _, err := ParseIntSet[int](value, 0); err == nil {
+			t.Fatalf("%q: expected error", value)
+		}
+	}
+
+	_, err := ParseIntSet[int64]("1-999999999", 0)
+	if !errors.Is(err, ErrSetTooLarge) {
+		t.Fatalf("want ErrSetTooLarge, got %v", err)
+	}
+
+	_, err = ParseIntSet[uint64]("0-18446744073709551615", 0)
+	if !errors.Is(err, ErrSetTooLarge) {
+		t.Fatalf("want ErrSetTooLarge, got %v", err)
+	}
+
+	_, err = ParseIntSet[int]("1-6,1-6", 10)
+	if !errors.Is(err, ErrSetTooLarge) {
+		t.Fatalf("want ErrSetTooLarge, got %v", err)
+	}
+
+	_, err = ParseIntSet[uint8]("1-300", 0)
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("want strconv.ErrRange, got %v", err)
+	}
+}
+
+func TestQueryIntSet(t *testing.T) {
+	req := newQueryRequest(t, "ids=1-3,7&ids=4")
+
+	got, err := QueryIntSet[uint32](req, "ids", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []uint32{1, 2, 3, 4, 7}
+	if !reflect.DeepEqual(want, got.Values()) {
+		t.Fatalf("want %v, got %v", want, got.Values())
+	}
+
+	_, err = QueryIntSet[uint32](newQueryRequest(t, "ids=1-1000"), "ids", 100)
+	if !errors.Is(err, ErrInvalidParam) || !errors.Is(err, ErrSetTooLarge) {
+		t.Fatalf("want ErrSetTooLarge, got %v", err)
+	}
+}
+
+func TestPathIntSet(t *testing.T) {
+	req, key := newParamRequest(t, "1-3,5")
+
+	got, err := PathIntSet[int](req, key, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.String() != "1-3,5" {
+		t.Fatalf("want %s, got %s", "1-3,5", got)
+	}
+}
@@ -0,0 +1,180 @@
+package param
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOption configures how time parameters are parsed
+type TimeOption func(*timeOptions)
+
+type timeOptions struct {
+	relative bool
+	now      func() time.Time
+}
+
+// RelativeTime makes time getters accept relative expressions next to
+// RFC 3339 timestamps, see ParseRelativeTime. Expressions are evaluated
+// against now, a nil now uses time.Now.
+func RelativeTime(now func() time.Time) TimeOption {
+	return func(o *timeOptions) {
+		o.relative = true
+		o.now = now
+	}
+}
+
+// timeParser returns the parse function for the given options
+func timeParser(opts []TimeOption) func(string) (time.Time, error) {
+	var o timeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.relative {
+		return parseTime
+	}
+	now := o.now
+	if now == nil {
+		now = time.Now
+	}
+	return func(value string) (time.Time, error) {
+		t, err := parseTime(value)
+		if err == nil || !isRelativeTime(value) {
+			// values that are no expression keep the RFC 3339 error
+			return t, err
+		}
+		return ParseRelativeTime(value, now())
+	}
+}
+
+var relativeAliases = map[string]string{
+	"today":     "now/d",
+	"yesterday": "now-1d/d",
+	"tomorrow":  "now+1d/d",
+}
+
+// isRelativeTime reports whether value is meant as a relative expression
+func isRelativeTime(value string) bool {
+	_, alias := relativeAliases[value]
+	return alias || strings.HasPrefix(value, "now")
+}
+
+// ParseRelativeTime evaluates a Grafana style relative time expression
+// against now. Expressions start with "now", followed by any number of
+// offsets such as "-15m" or "+1d" and an optional rounding such as "/d"
+// that truncates to the start of the unit. Units are s, m, h, d, w
+// (weeks start on Monday), M and y. The words "today", "yesterday" and
+// "tomorrow" stand for "now/d", "now-1d/d" and "now+1d/d". Days and
+// larger units follow the calendar in the location of now.
+func ParseRelativeTime(value string, now time.Time) (time.Time, error) {
+	expr := value
+	if alias, ok := relativeAliases[expr]; ok {
+		expr = alias
+	}
+	if !strings.HasPrefix(expr, "now") {
+		return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+	}
+
+	t, rest := now, expr[3:]
+	for len(rest) > 0 {
+		op := rest[0]
+		rest = rest[1:]
+
+		end := 0
+		for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+			end++
+		}
+		if end == len(rest) {
+			return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+		}
+		unit := rest[end]
+
+		switch op {
+		case '+', '-':
+			if end == 0 {
+				return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+			}
+			n, err := strconv.Atoi(rest[:end])
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid relative time %q: %w", value, err)
+			}
+			if op == '-' {
+				n = -n
+			}
+			if t, err = addUnit(t, n, unit); err != nil {
+				return time.Time{}, fmt.Errorf("invalid relative time %q: %w", value, err)
+			}
+		case '/':
+			// rounding ends the expression
+			if end != 0 || end+1 != len(rest) {
+				return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+			}
+			var err error
+			if t, err = truncateUnit(t, unit); err != nil {
+				return time.Time{}, fmt.Errorf("invalid relative time %q: %w", value, err)
+			}
+		default:
+			return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+		}
+		rest = rest[end+1:]
+	}
+	return t, nil
+}
+
+// relativeUnits are the longest span of each unit, offsets are limited to
+// what fits a time.Duration, about 292 years
+var relativeUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+	'M': 31 * 24 * time.Hour,
+	'y': 366 * 24 * time.Hour,
+}
+
+func addUnit(t time.Time, n int, unit byte) (time.Time, error) {
+	span, ok := relativeUnits[unit]
+	if !ok {
+		return time.Time{}, fmt.Errorf("unknown unit %q", unit)
+	}
+	if n > int(math.MaxInt64/span) || n < -int(math.MaxInt64/span) {
+		return time.Time{}, fmt.Errorf("%w: offset %d%c is out of range", ErrInvalidParam, n, unit)
+	}
+	switch unit {
+	case 'd':
+		return t.AddDate(0, 0, n), nil
+	case 'w':
+		return t.AddDate(0, 0, 7*n), nil
+	case 'M':
+		return t.AddDate(0, n, 0), nil
+	case 'y':
+		return t.AddDate(n, 0, 0), nil
+	}
+	return t.Add(time.Duration(n) * span), nil
+}
+
+func truncateUnit(t time.Time, unit byte) (time.Time, error) {
+	y, mo, d := t.Date()
+	loc := t.Location()
+	switch unit {
+	case 's':
+		return time.Date(y, mo, d, t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+	case 'm':
+		return time.Date(y, mo, d, t.Hour(), t.Minute(), 0, 0, loc), nil
+	case 'h':
+		return time.Date(y, mo, d, t.Hour(), 0, 0, 0, loc), nil
+	case 'd':
+		return time.Date(y, mo, d, 0, 0, 0, 0, loc), nil
+	case 'w':
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, mo, d-offset, 0, 0, 0, 0, loc), nil
+	case 'M':
+		return time.Date(y, mo, 1, 0, 0, 0, 0, loc), nil
+	case 'y':
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, loc), nil
+	}
+	return time.Time{}, fmt.Errorf("unknown unit %q", unit)
+}
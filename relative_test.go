@@ -0,0 +1,131 @@
+package param
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Wednesday
+var relativeNow = time.Date(2024, 3, 13, 14, 35, 20, 0, time.UTC)
+
+func TestParseRelativeTime(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"now", relativeNow},
+		{"now-15m", relativeNow.Add(-15 * time.Minute)},
+		{"now+1h", relativeNow.Add(time.Hour)},
+		{"now-1d-2h", relativeNow.Add(-26 * time.Hour)},
+		{"now-1d/d", time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{"now/w", time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"now-1M/M", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"now/y", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"now/h", time.Date(2024, 3, 13, 14, 0, 0, 0, time.UTC)},
+		{"today", time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)},
+		{"yesterday", time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow", time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRelativeTime(tt.value, relativeNow)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if !got.Equal(tt.want) {
+			t.Fatalf("%s: want %v, got %v", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestParseRelativeTimeErr(t *testing.T) {
+	for _, value := range []string{"", "then", "now-", "now-d", "now-1x", "now/d-1h", "now/1d", "now*2d", "now/x", "nowish"} {
+		if _, err := ParseRelativeTime(value, relativeNow); err == nil {
+			t.Fatalf("%q: expected error", value)
+		}
+	}
+}
+
+func TestParseRelativeTimeRange(t *testing.T) {
+	for _, value := range []string{"now-99999999999h", "now+9999999999999s", "now-999999999d", "now+99999999w", "now-9999999M", "now+999999y", "now-99999999999999999999d"} {
+		_, err := ParseRelativeTime(value, relativeNow)
+		if !errors.Is(err, ErrInvalidParam) && !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("%q: want out of range error, got %v", value, err)
+		}
+	}
+
+	if _, err := ParseRelativeTime("now-100y", relativeNow); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryTimeRelative(t *testing.T) {
+	clock := func() time.Time { return relativeNow }
+	req := newQueryRequest(t, "since=now-24h&until=now+1d/d&at=2024-01-02T03:04:05Z")
+
+	since, err := QueryTime(req, "since", RelativeTime(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !since.Equal(relativeNow.Add(-24 * time.Hour)) {
+		t.Fatalf("unexpected since %v", since)
+	}
+
+	until, err := QueryTime(req, "until", RelativeTime(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !until.Equal(time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected until %v", until)
+	}
+
+	at, err := QueryTime(req, "at", RelativeTime(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !at.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("unexpected at %v", at)
+	}
+
+	_, err = QueryTime(req, "since")
+	if err == nil {
+		t.Fatal("relative expressions should need the RelativeTime option")
+	}
+
+	// a broken timestamp keeps the RFC 3339 error
+	req = newQueryRequest(t, "at=2024-13-02T03:04:05Z")
+	_, err = QueryTime(req, "at", RelativeTime(clock))
+	var parseErr *time.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("want *time.ParseError, got %v", err)
+	}
+}
+
+func TestTimeRelative(t *testing.T) {
+	req, key := newParamRequest(t, "today")
+
+	got, err := Time(req, key, RelativeTime(func() time.Time { return relativeNow }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected time %v", got)
+	}
+}
+
+func TestQueryTimeRangeRelative(t *testing.T) {
+	req := newQueryRequest(t, "from=now-7d/d&to=today")
+
+	got, err := QueryTimeRange(req, "from", "to", 0, RelativeTime(func() time.Time { return relativeNow }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Duration() != 7*24*time.Hour {
+		t.Fatalf("want 7 days, got %s", got.Duration())
+	}
+}
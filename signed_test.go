@@ -0,0 +1,119 @@
+package param
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newSigner(now time.Time) *URLSigner {
+	return &URLSigner{
+		KeyID: "2",
+		Keys:  map[string][]byte{"1": []byte("old secret"), "2": []byte("new secret")},
+		Now:   func() time.Time { return now },
+	}
+}
+
+func TestURLSigner(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newSigner(now)
+
+	u, _ := url.Parse("/files/42?download=1")
+	signed, err := s.Sign(u, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Verify(httptest.NewRequest("GET", signed.String(), nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	// URLs signed with a rotated out key stay valid while the key is listed
+	old := newSigner(now)
+	old.KeyID = "1"
+	oldSigned, _ := old.Sign(u, time.Hour)
+	if err := s.Verify(httptest.NewRequest("GET", oldSigned.String(), nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Now = func() time.Time { return now.Add(time.Hour) }
+	err = s.Verify(httptest.NewRequest("GET", signed.String(), nil))
+	if !errors.Is(err, ErrSignatureExpired) || !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrSignatureExpired, got %v", err)
+	}
+}
+
+func TestURLSignerTampered(t *testing.T) {
+	s := newSigner(time.Now())
+
+	u, _ := url.Parse("/files/42?download=1")
+	signed, _ := s.Sign(u, time.Hour)
+
+	tests := map[string]func(u *url.URL){
+		"path":     func(u *url.URL) { u.Path = "/files/43" },
+		"changed":  func(u *url.URL) { u.RawQuery = setQuery(u, "download", "0") },
+		"added":    func(u *url.URL) { u.RawQuery += "&admin=1" },
+		"repeated": func(u *url.URL) { u.RawQuery += "&download=1" },
+		"expiry":   func(u *url.URL) { u.RawQuery = setQuery(u, ExpiresKey, "99999999999") },
+		"no sig":   func(u *url.URL) { u.RawQuery = setQuery(u, SignatureKey, "") },
+	}
+
+	for name, tamper := range tests {
+		tampered := *signed
+		tamper(&tampered)
+		err := s.Verify(httptest.NewRequest("GET", tampered.String(), nil))
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("%s: want ErrInvalidSignature, got %v", name, err)
+		}
+	}
+
+	unknown := *signed
+	unknown.RawQuery = setQuery(&unknown, KeyIDKey, "3")
+	err := s.Verify(httptest.NewRequest("GET", unknown.String(), nil))
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("want ErrUnknownKey, got %v", err)
+	}
+
+	s.KeyID = "3"
+	if _, err := s.Sign(u, time.Hour); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("want ErrUnknownKey, got %v", err)
+	}
+}
+
+func setQuery(u *url.URL, key, value string) string {
+	query := u.Query()
+	query.Set(key, value)
+	return query.Encode()
+}
+
+func TestURLSignerMiddleware(t *testing.T) {
+	s := newSigner(time.Now())
+
+	r := chi.NewRouter()
+	r.With(s.Middleware).Get("/files/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := Int(r, "id")
+		if err != nil || id != 42 {
+			t.Errorf("unexpected id %d, %v", id, err)
+		}
+	})
+
+	u, _ := url.Parse("/files/42")
+	signed, _ := s.Sign(u, time.Minute)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", signed.String(), nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/files/42", nil))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("want 403, got %d", w.Code)
+	}
+}
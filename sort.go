@@ -0,0 +1,96 @@
+package param
+
+import (
+	"net/http"
+	"strings"
+)
+
+// SortTerm is a single field of a sort parameter
+type SortTerm struct {
+	Field string
+	Desc  bool
+}
+
+// String returns the term in "-field" form
+func (t SortTerm) String() string {
+	if t.Desc {
+		return "-" + t.Field
+	}
+	return t.Field
+}
+
+// QuerySort returns the sort order of a query parameter such as
+// "sort=-created_at,name". Terms are comma separated and may be repeated
+// across keys, a "-" or "+" prefix or an ":asc" or ":desc" suffix sets the
+// direction. Fields not in allowed and fields listed twice are rejected.
+func QuerySort(r *http.Request, key string, allowed []string) ([]SortTerm, error) {
+	values, err := QueryStringArray(r, key)
+	if err != nil {
+		return nil, err
+	}
+
+	allow := make(map[string]bool, len(allowed))
+	for _, field := range allowed {
+		allow[field] = true
+	}
+
+	var out []SortTerm
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, raw := range strings.Split(value, ",") {
+			term, err := parseSortTerm(key, raw)
+			if err != nil {
+				return nil, err
+			}
+			if !allow[term.Field] {
+				return nil, invalidParam(key, value, "unknown sort field "+term.Field)
+			}
+			if seen[term.Field] {
+				return nil, invalidParam(key, value, "duplicate sort field "+term.Field)
+			}
+			seen[term.Field] = true
+			out = append(out, term)
+		}
+	}
+	return out, nil
+}
+
+func parseSortTerm(key, raw string) (SortTerm, error) {
+	// a leading space is a + stripped out during url parse stage
+	term := unplus(strings.TrimRight(raw, " "))
+
+	var t SortTerm
+	switch {
+	case strings.HasPrefix(term, "-"):
+		t.Desc = true
+		term = term[1:]
+	case strings.HasPrefix(term, "+"):
+		term = term[1:]
+	default:
+		if field, dir, ok := strings.Cut(term, ":"); ok {
+			switch strings.ToLower(dir) {
+			case "asc":
+			case "desc":
+				t.Desc = true
+			default:
+				return SortTerm{}, invalidParam(key, raw, "invalid sort direction "+dir)
+			}
+			term = field
+		}
+	}
+
+	if len(term) == 0 {
+		return SortTerm{}, invalidParam(key, raw, "empty sort field")
+	}
+	t.Field = term
+	return t, nil
+}
+
+// Sort sets a query parameter with sort terms in "-field,field" form
+func (q *Query) Sort(key string, terms []SortTerm) *Query {
+	out := make([]string, len(terms))
+	for index, term := range terms {
+		out[index] = term.String()
+	}
+	return q.set(key, []string{strings.Join(out, ",")})
+}
@@ -0,0 +1,66 @@
+package param
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var sortFields = []string{"created_at", "name", "id"}
+
+func TestQuerySort(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []SortTerm
+	}{
+		{"sort=-created_at,name", []SortTerm{{"created_at", true}, {"name", false}}},
+		{"sort=%2Bname,-id", []SortTerm{{"name", false}, {"id", true}}},
+		{"sort=+name", []SortTerm{{"name", false}}},
+		{"sort=name:desc,id:ASC", []SortTerm{{"name", true}, {"id", false}}},
+		{"sort=name&sort=-id", []SortTerm{{"name", false}, {"id", true}}},
+	}
+
+	for _, tt := range tests {
+		got, err := QuerySort(newQueryRequest(t, tt.query), "sort", sortFields)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.query, err)
+		}
+
+		if !reflect.DeepEqual(tt.want, got) {
+			t.Fatalf("%s: want %v, got %v", tt.query, tt.want, got)
+		}
+	}
+}
+
+func TestQuerySortErr(t *testing.T) {
+	tests := []string{
+		"sort=password",
+		"sort=name,-name",
+		"sort=name&sort=name:desc",
+		"sort=name:up",
+		"sort=name,,id",
+		"sort=-",
+		"order=name",
+	}
+
+	for _, query := range tests {
+		_, err := QuerySort(newQueryRequest(t, query), "sort", sortFields)
+		if !errors.Is(err, ErrInvalidParam) {
+			t.Fatalf("%s: want ErrInvalidParam, got %v", query, err)
+		}
+	}
+}
+
+func TestQueryBuilderSort(t *testing.T) {
+	terms := []SortTerm{{"created_at", true}, {"name", false}}
+	req := newQueryRequest(t, NewQuery().Sort("sort", terms).Encode())
+
+	got, err := QuerySort(req, "sort", sortFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(terms, got) {
+		t.Fatalf("want %v, got %v", terms, got)
+	}
+}
@@ -0,0 +1,234 @@
+// Package sqlfilter translates filter expressions parsed from request
+// parameters into parameterized SQL. Field names are mapped to columns so
+// request input never reaches the SQL text, operands are always passed as
+// arguments.
+package sqlfilter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oceanicdev/chi-param"
+)
+
+// ErrUnmappedField is an error for a field without a column mapping
+var ErrUnmappedField = errors.New("Field has no column mapping")
+
+// ErrUnsupportedExpr is an error for an expression the translator does not know
+var ErrUnsupportedExpr = errors.New("Unsupported filter expression")
+
+// Dialect selects the placeholder style
+type Dialect int
+
+const (
+	// Postgres uses numbered $1 placeholders
+	Postgres Dialect = iota
+	// MySQL uses ? placeholders
+	MySQL
+	// SQLite uses ? placeholders
+	SQLite
+)
+
+var comparisons = map[param.FilterOp]string{
+	param.FilterEq: "=",
+	param.FilterNe: "<>",
+	param.FilterLt: "<",
+	param.FilterLe: "<=",
+	param.FilterGt: ">",
+	param.FilterGe: ">=",
+}
+
+// Translator turns filter expressions into WHERE clause fragments. Columns
+// maps field names to trusted SQL column expressions. ArgOffset is the
+// number of arguments already bound before the fragment, so numbered
+// placeholders continue after them.
+type Translator struct {
+	Dialect   Dialect
+	Columns   map[string]string
+	ArgOffset int
+}
+
+// Where translates expr with a translator for the dialect and columns
+func Where(expr param.FilterExpr, dialect Dialect, columns map[string]string) (string, []interface{}, error) {
+	return Translator{Dialect: dialect, Columns: columns}.Where(expr)
+}
+
+// Where returns the SQL condition for expr and its arguments. Groups are
+// parenthesized at the top level too, so the condition can be joined with
+// others by AND.
+func (t Translator) Where(expr param.FilterExpr) (string, []interface{}, error) {
+	b := &builder{t: t}
+	if err := b.expr(expr, true); err != nil {
+		return "", nil, err
+	}
+	return b.sql.String(), b.args, nil
+}
+
+// OrderBy returns an ORDER BY list such as "created_at DESC, name ASC"
+func (t Translator) OrderBy(terms []param.SortTerm) (string, error) {
+	out := make([]string, len(terms))
+	for index, term := range terms {
+		column, err := t.column(term.Field)
+		if err != nil {
+			return "", err
+		}
+		dir := "ASC"
+		if term.Desc {
+			dir = "DESC"
+		}
+		out[index] = column + " " + dir
+	}
+	return strings.Join(out, ", "), nil
+}
+
+func (t Translator) column(field string) (string, error) {
+	column, ok := t.Columns[field]
+	if !ok || len(column) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrUnmappedField, field)
+	}
+	return column, nil
+}
+
+type builder struct {
+	t    Translator
+	sql  strings.Builder
+	args []interface{}
+}
+
+func (b *builder) placeholder(arg interface{}) string {
+	b.args = append(b.args, arg)
+	if b.t.Dialect == Postgres {
+		return "$" + strconv.Itoa(b.t.ArgOffset+len(b.args))
+	}
+	return "?"
+}
+
+// expr writes an expression, nested groups are parenthesized
+func (b *builder) expr(expr param.FilterExpr, nested bool) error {
+	switch e := expr.(type) {
+	case param.FilterAnd:
+		return b.group(e.Exprs, " AND ", nested)
+	case param.FilterOr:
+		return b.group(e.Exprs, " OR ", nested)
+	case param.FilterNot:
+		b.sql.WriteString("NOT (")
+		if err := b.expr(e.Expr, false); err != nil {
+			return err
+		}
+		b.sql.WriteString(")")
+		return nil
+	case param.FilterComparison:
+		return b.comparison(e)
+	}
+	return fmt.Errorf("%w: %T", ErrUnsupportedExpr, expr)
+}
+
+func (b *builder) group(exprs []param.FilterExpr, sep string, nested bool) error {
+	// an empty group would render as "()", use the identity of its operator
+	if len(exprs) == 0 {
+		if sep == " AND " {
+			b.sql.WriteString("1 = 1")
+		} else {
+			b.sql.WriteString("1 = 0")
+		}
+		return nil
+	}
+	if nested {
+		b.sql.WriteString("(")
+	}
+	for index, expr := range exprs {
+		if index > 0 {
+			b.sql.WriteString(sep)
+		}
+		if err := b.expr(expr, true); err != nil {
+			return err
+		}
+	}
+	if nested {
+		b.sql.WriteString(")")
+	}
+	return nil
+}
+
+func (b *builder) comparison(c param.FilterComparison) error {
+	column, err := b.t.column(c.Field)
+	if err != nil {
+		return err
+	}
+
+	switch c.Op {
+	case param.FilterIn, param.FilterOut:
+		if len(c.Values) == 0 {
+			// an empty set matches nothing, its complement everything
+			if c.Op == param.FilterIn {
+				b.sql.WriteString("1 = 0")
+			} else {
+				b.sql.WriteString("1 = 1")
+			}
+			return nil
+		}
+		b.sql.WriteString(column)
+		if c.Op == param.FilterOut {
+			b.sql.WriteString(" NOT")
+		}
+		b.sql.WriteString(" IN (")
+		for index, value := range c.Values {
+			if index > 0 {
+				b.sql.WriteString(", ")
+			}
+			b.sql.WriteString(b.placeholder(value))
+		}
+		b.sql.WriteString(")")
+		return nil
+	case param.FilterContains, param.FilterStartsWith, param.FilterEndsWith:
+		value, ok := single(c).(string)
+		if !ok {
+			return fmt.Errorf("%w: %s expects a string for %s", ErrUnsupportedExpr, c.Op, c.Field)
+		}
+		pattern := escapeLike(value)
+		switch c.Op {
+		case param.FilterContains:
+			pattern = "%" + pattern + "%"
+		case param.FilterStartsWith:
+			pattern += "%"
+		case param.FilterEndsWith:
+			pattern = "%" + pattern
+		}
+		fmt.Fprintf(&b.sql, "%s LIKE %s ESCAPE '!'", column, b.placeholder(pattern))
+		return nil
+	}
+
+	op, ok := comparisons[c.Op]
+	if !ok || len(c.Values) != 1 {
+		return fmt.Errorf("%w: operator %s", ErrUnsupportedExpr, c.Op)
+	}
+	value := c.Values[0]
+	if value == nil {
+		switch c.Op {
+		case param.FilterEq:
+			b.sql.WriteString(column + " IS NULL")
+		case param.FilterNe:
+			b.sql.WriteString(column + " IS NOT NULL")
+		default:
+			return fmt.Errorf("%w: operator %s with null", ErrUnsupportedExpr, c.Op)
+		}
+		return nil
+	}
+	fmt.Fprintf(&b.sql, "%s %s %s", column, op, b.placeholder(value))
+	return nil
+}
+
+func single(c param.FilterComparison) interface{} {
+	if len(c.Values) != 1 {
+		return nil
+	}
+	return c.Values[0]
+}
+
+// escapeLike escapes LIKE wildcards with "!", which needs no quoting in
+// any of the supported dialects
+func escapeLike(value string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(value)
+}
@@ -0,0 +1,138 @@
+package sqlfilter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oceanicdev/chi-param"
+	"github.com/oceanicdev/chi-param/odata"
+)
+
+var (
+	schema = param.FilterSchema{
+		"status":   param.FilterString,
+		"priority": param.FilterInt,
+		"owner":    param.FilterString,
+		"name":     param.FilterString,
+		"deleted":  param.FilterTime,
+	}
+	columns = map[string]string{
+		"status":   "t.status",
+		"priority": "t.priority",
+		"owner":    "t.owner_login",
+		"name":     "t.name",
+		"deleted":  "t.deleted_at",
+	}
+)
+
+func TestWhere(t *testing.T) {
+	expr, err := param.ParseFilter("status==open;priority=gt=3,owner=out=(a,b)", schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{Postgres, "((t.status = $1 AND t.priority > $2) OR t.owner_login NOT IN ($3, $4))"},
+		{MySQL, "((t.status = ? AND t.priority > ?) OR t.owner_login NOT IN (?, ?))"},
+		{SQLite, "((t.status = ? AND t.priority > ?) OR t.owner_login NOT IN (?, ?))"},
+	}
+
+	for _, tt := range tests {
+		got, args, err := Where(expr, tt.dialect, columns)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("want %s, got %s", tt.want, got)
+		}
+
+		wantArgs := []interface{}{"open", int64(3), "a", "b"}
+		if !reflect.DeepEqual(wantArgs, args) {
+			t.Fatalf("want %v, got %v", wantArgs, args)
+		}
+	}
+}
+
+func TestWhereOData(t *testing.T) {
+	expr, err := odata.ParseFilter("not (deleted ne null) and contains(name, '50%_off!')", schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := Translator{Dialect: Postgres, Columns: columns, ArgOffset: 2}
+	got, args, err := tr.Where(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "(NOT (t.deleted_at IS NOT NULL) AND t.name LIKE $3 ESCAPE '!')"
+	if got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+
+	wantArgs := []interface{}{"%50!%!_off!!%"}
+	if !reflect.DeepEqual(wantArgs, args) {
+		t.Fatalf("want %v, got %v", wantArgs, args)
+	}
+}
+
+func TestWhereErr(t *testing.T) {
+	expr, err := param.ParseFilter("status==open", schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = Where(expr, Postgres, map[string]string{})
+	if !errors.Is(err, ErrUnmappedField) {
+		t.Fatalf("want ErrUnmappedField, got %v", err)
+	}
+
+	_, _, err = Where(nil, Postgres, columns)
+	if !errors.Is(err, ErrUnsupportedExpr) {
+		t.Fatalf("want ErrUnsupportedExpr, got %v", err)
+	}
+
+	lt := param.FilterComparison{Field: "status", Op: param.FilterLt, Values: []interface{}{nil}}
+	_, _, err = Where(lt, Postgres, columns)
+	if !errors.Is(err, ErrUnsupportedExpr) {
+		t.Fatalf("want ErrUnsupportedExpr, got %v", err)
+	}
+}
+
+func TestWhereEmptyGroup(t *testing.T) {
+	status := param.FilterComparison{Field: "status", Op: param.FilterEq, Values: []interface{}{"open"}}
+	expr := param.FilterOr{Exprs: []param.FilterExpr{param.FilterAnd{}, param.FilterNot{Expr: param.FilterOr{}}, status}}
+
+	got, args, err := Where(expr, Postgres, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "(1 = 1 OR NOT (1 = 0) OR t.status = $1)"; got != want || len(args) != 1 {
+		t.Fatalf("want %q, got %q %v", want, got, args)
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	tr := Translator{Columns: columns}
+
+	got, err := tr.OrderBy([]param.SortTerm{{Field: "priority", Desc: true}, {Field: "name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "t.priority DESC, t.name ASC"
+	if got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+
+	_, err = tr.OrderBy([]param.SortTerm{{Field: "secret"}})
+	if !errors.Is(err, ErrUnmappedField) {
+		t.Fatalf("want ErrUnmappedField, got %v", err)
+	}
+}
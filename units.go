@@ -0,0 +1,249 @@
+package param
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteSize is a number of bytes
+type ByteSize uint64
+
+// SI and IEC byte size units
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1 << 10
+	MiB ByteSize = 1 << 20
+	GiB ByteSize = 1 << 30
+	TiB ByteSize = 1 << 40
+	PiB ByteSize = 1 << 50
+	EiB ByteSize = 1 << 60
+)
+
+var byteUnits = map[string]ByteSize{
+	"":    Byte,
+	"b":   Byte,
+	"k":   KB,
+	"kb":  KB,
+	"m":   MB,
+	"mb":  MB,
+	"g":   GB,
+	"gb":  GB,
+	"t":   TB,
+	"tb":  TB,
+	"p":   PB,
+	"pb":  PB,
+	"e":   EB,
+	"eb":  EB,
+	"kib": KiB,
+	"mib": MiB,
+	"gib": GiB,
+	"tib": TiB,
+	"pib": PiB,
+	"eib": EiB,
+}
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+// String returns the size with the largest IEC unit that keeps it whole
+func (b ByteSize) String() string {
+	units := []struct {
+		name string
+		size ByteSize
+	}{{"EiB", EiB}, {"PiB", PiB}, {"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB}}
+	for _, unit := range units {
+		if b >= unit.size && b%unit.size == 0 {
+			return strconv.FormatUint(uint64(b/unit.size), 10) + unit.name
+		}
+	}
+	return strconv.FormatUint(uint64(b), 10) + "B"
+}
+
+// splitNumber splits a leading decimal number such as "1.5" from the rest
+func splitNumber(value string) (string, string) {
+	end, dot := 0, false
+	for end < len(value) {
+		c := value[end]
+		if c == '.' && !dot {
+			dot = true
+		} else if c < '0' || c > '9' {
+			break
+		}
+		end++
+	}
+	return value[:end], value[end:]
+}
+
+// scaleDecimal returns number times unit exactly, truncating any fraction
+// left over, and fails if the result exceeds max
+func scaleDecimal(number string, unit, max uint64) (uint64, bool) {
+	r, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, false
+	}
+	r.Mul(r, new(big.Rat).SetUint64(unit))
+	v := new(big.Int).Quo(r.Num(), r.Denom())
+	if !v.IsUint64() || v.Uint64() > max {
+		return 0, false
+	}
+	return v.Uint64(), true
+}
+
+func numError(fn, value string, err error) error {
+	return &strconv.NumError{Func: fn, Num: value, Err: err}
+}
+
+// ParseByteSize parses a byte size such as "512", "10MB" or "1.5GiB". SI
+// units are powers of 1000 and IEC units powers of 1024, units are case
+// insensitive and may be separated from the number by a space.
+func ParseByteSize(value string) (ByteSize, error) {
+	number, unit := splitNumber(value)
+	if len(number) == 0 || number == "." {
+		return 0, numError("ParseByteSize", value, strconv.ErrSyntax)
+	}
+	scale, ok := byteUnits[strings.ToLower(strings.TrimPrefix(unit, " "))]
+	if !ok {
+		return 0, numError("ParseByteSize", value, strconv.ErrSyntax)
+	}
+	v, ok := scaleDecimal(number, uint64(scale), math.MaxUint64)
+	if !ok {
+		return 0, numError("ParseByteSize", value, strconv.ErrRange)
+	}
+	return ByteSize(v), nil
+}
+
+// ParseDuration parses a duration such as "1d12h", "2w" or "1.5h" or an
+// ISO 8601 duration such as "PT1H30M". Next to the units of
+// time.ParseDuration it accepts days ("d") and weeks ("w") of 24 hours and
+// 7 days. ISO 8601 years and months have no fixed length and are rejected.
+func ParseDuration(value string) (time.Duration, error) {
+	neg, rest := false, value
+	if len(rest) > 0 && (rest[0] == '-' || rest[0] == '+') {
+		neg, rest = rest[0] == '-', rest[1:]
+	}
+
+	if strings.HasPrefix(rest, "P") {
+		iso, err := ParseISODuration(rest)
+		if errors.Is(err, ErrInvalidParam) {
+			// calendar units past their bound
+			return 0, numError("ParseDuration", value, strconv.ErrRange)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if iso.Years != 0 || iso.Months != 0 {
+			return 0, numError("ParseDuration", value, strconv.ErrSyntax)
+		}
+		weeks, ok := scaleDecimal(strconv.Itoa(iso.Weeks), uint64(7*24*time.Hour), math.MaxInt64-uint64(iso.Clock))
+		if !ok {
+			return 0, numError("ParseDuration", value, strconv.ErrRange)
+		}
+		days, ok := scaleDecimal(strconv.Itoa(iso.Days), uint64(24*time.Hour), math.MaxInt64-uint64(iso.Clock)-weeks)
+		if !ok {
+			return 0, numError("ParseDuration", value, strconv.ErrRange)
+		}
+		out := time.Duration(weeks+days) + iso.Clock
+		if neg {
+			out = -out
+		}
+		return out, nil
+	}
+
+	if rest == "0" {
+		return 0, nil
+	}
+	if len(rest) == 0 {
+		return 0, numError("ParseDuration", value, strconv.ErrSyntax)
+	}
+
+	var total uint64
+	for len(rest) > 0 {
+		number, tail := splitNumber(rest)
+		if len(number) == 0 || number == "." {
+			return 0, numError("ParseDuration", value, strconv.ErrSyntax)
+		}
+		end := 0
+		for end < len(tail) && !(tail[end] >= '0' && tail[end] <= '9' || tail[end] == '.') {
+			end++
+		}
+		unit, ok := durationUnits[tail[:end]]
+		if !ok {
+			return 0, numError("ParseDuration", value, strconv.ErrSyntax)
+		}
+		v, ok := scaleDecimal(number, uint64(unit), math.MaxInt64-total)
+		if !ok {
+			return 0, numError("ParseDuration", value, strconv.ErrRange)
+		}
+		total += v
+		rest = tail[end:]
+	}
+
+	out := time.Duration(total)
+	if neg {
+		out = -out
+	}
+	return out, nil
+}
+
+// Duration returns a path parameter as a duration type, see ParseDuration
+func Duration(r *http.Request, key string) (time.Duration, error) {
+	return pathID(r, key, ParseDuration)
+}
+
+// Bytes returns a path parameter as a byte size type, see ParseByteSize
+func Bytes(r *http.Request, key string) (ByteSize, error) {
+	return pathID(r, key, ParseByteSize)
+}
+
+// QueryDurationArray returns a slice of query parameters with duration type
+func QueryDurationArray(r *http.Request, key string) ([]time.Duration, error) {
+	return queryIDArray(r, key, func(value string) (time.Duration, error) {
+		return ParseDuration(unplus(value))
+	})
+}
+
+// QueryBytesArray returns a slice of query parameters with byte size type
+func QueryBytesArray(r *http.Request, key string) ([]ByteSize, error) {
+	// sizes have no sign, a space before the unit is kept as it is
+	return queryIDArray(r, key, ParseByteSize)
+}
+
+// QueryDuration returns a query parameter with duration type
+func QueryDuration(r *http.Request, key string) (time.Duration, error) {
+	values, err := QueryDurationArray(r, key)
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
+
+// QueryBytes returns a query parameter with byte size type
+func QueryBytes(r *http.Request, key string) (ByteSize, error) {
+	values, err := QueryBytesArray(r, key)
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
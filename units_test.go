@@ -0,0 +1,186 @@
+package param
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"0", 0},
+		{"1d12h", 36 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1.5h", 90 * time.Minute},
+		{"-15m", -15 * time.Minute},
+		{"1h30m10s500ms", time.Hour + 30*time.Minute + 10*time.Second + 500*time.Millisecond},
+		{"PT1H30M", 90 * time.Minute},
+		{"P1W2DT3H", 9*24*time.Hour + 3*time.Hour},
+		{"2562047h47m16.854775807s", math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("%s: want %s, got %s", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestParseDurationErr(t *testing.T) {
+	for _, value := range []string{"", "-", "1", "1x", "h", "1h30", ".h", "P1Y", "P1M", "PT"} {
+		if _, err := ParseDuration(value); err == nil {
+			t.Fatalf("%q: expected error", value)
+		}
+	}
+
+	for _, value := range []string{"106752d", "2562047h47m16.854775808s", "P15251W", "9223372036854775808ns", "PT9223372036.854775808S", "P1DT9223372036.854775807S"} {
+		_, err := ParseDuration(value)
+		if !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("%q: want strconv.ErrRange, got %v", value, err)
+		}
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ByteSize
+	}{
+		{"512", 512},
+		{"10MB", 10 * MB},
+		{"10 mb", 10 * MB},
+		{"1.5GiB", GiB + 512*MiB},
+		{"1k", KB},
+		{"15EiB", 15 * EiB},
+		{"18446744073709551615B", math.MaxUint64},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.value, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("%s: want %d, got %d", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestParseByteSizeErr(t *testing.T) {
+	for _, value := range []string{"", "MB", "10XB", "1.2.3MB", "-1MB", "10  MB"} {
+		_, err := ParseByteSize(value)
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("%q: want strconv.ErrSyntax, got %v", value, err)
+		}
+	}
+
+	for _, value := range []string{"16EiB", "18446744073709551616", "20EB"} {
+		_, err := ParseByteSize(value)
+		if !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("%q: want strconv.ErrRange, got %v", value, err)
+		}
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	tests := map[ByteSize]string{
+		0:             "0B",
+		1023:          "1023B",
+		KiB:           "1KiB",
+		GiB + 512*MiB: "1536MiB",
+		10 * MB:       "10000000B",
+		3 * EiB:       "3EiB",
+	}
+
+	for size, want := range tests {
+		if got := size.String(); got != want {
+			t.Fatalf("want %s, got %s", want, got)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	req, key := newParamRequest(t, "1d12h")
+
+	got, err := Duration(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != 36*time.Hour {
+		t.Fatalf("got %s, want %s", got, 36*time.Hour)
+	}
+}
+
+func TestBytes(t *testing.T) {
+	req, key := newParamRequest(t, "10MiB")
+
+	got, err := Bytes(req, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != 10*MiB {
+		t.Fatalf("got %d, want %d", got, 10*MiB)
+	}
+}
+
+func TestQueryDurationArray(t *testing.T) {
+	req := newQueryRequest(t, "ttl=1d&ttl=PT30M&ttl=+1h")
+
+	got, err := QueryDurationArray(req, "ttl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []time.Duration{24 * time.Hour, 30 * time.Minute, time.Hour}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	_, err = QueryDuration(req, "timeout")
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want ErrInvalidParam, got %v", err)
+	}
+}
+
+func TestQueryBytes(t *testing.T) {
+	req := newQueryRequest(t, "max=10MiB&min=lots&soft=10+MB&hard=10%20MB")
+
+	got, err := QueryBytes(req, "max")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != 10*MiB {
+		t.Fatalf("want %d, got %d", 10*MiB, got)
+	}
+
+	for _, key := range []string{"soft", "hard"} {
+		if got, err := QueryBytes(req, key); err != nil || got != 10*MB {
+			t.Fatalf("%s: want %d, got %d, %v", key, 10*MB, got, err)
+		}
+	}
+
+	_, err = QueryBytes(req, "min")
+	if !errors.Is(err, strconv.ErrSyntax) || !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("want strconv.ErrSyntax, got %v", err)
+	}
+
+	var paramErr *Error
+	if !errors.As(err, &paramErr) || paramErr.Key != "min" || paramErr.Value != "lots" {
+		t.Fatalf("want *Error for min, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package param
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// ErrForbiddenURL is an error for a URL that is valid but not allowed by policy
+var ErrForbiddenURL = errors.New("URL is not allowed")
+
+// Resolver looks up the addresses of a host, *net.Resolver implements it
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// URLOption configures which URLs are accepted
+type URLOption func(*urlOptions)
+
+type urlOptions struct {
+	schemes  []string
+	allow    []string
+	deny     []string
+	resolver Resolver
+	addrOpts []NetOption
+}
+
+// Schemes accepts only the given schemes, by default http and https
+func Schemes(schemes ...string) URLOption {
+	return func(o *urlOptions) {
+		o.schemes = schemes
+	}
+}
+
+// AllowHosts accepts only the given hosts. A leading "*." matches any
+// subdomain, "*.example.com" matches "api.example.com" but not
+// "example.com".
+func AllowHosts(hosts ...string) URLOption {
+	return func(o *urlOptions) {
+		o.allow = append(o.allow, hosts...)
+	}
+}
+
+// DenyHosts rejects the given hosts, with the same patterns as AllowHosts
+func DenyHosts(hosts ...string) URLOption {
+	return func(o *urlOptions) {
+		o.deny = append(o.deny, hosts...)
+	}
+}
+
+// AddrPolicy sets the policy for IP address hosts and resolved addresses,
+// by default PublicOnly
+func AddrPolicy(opts ...NetOption) URLOption {
+	return func(o *urlOptions) {
+		o.addrOpts = opts
+	}
+}
+
+// Resolve looks the host up with resolver and rejects the URL unless every
+// address passes the address policy. This blocks names that point to
+// internal addresses, but the caller has to connect to the checked
+// addresses, or check again when dialing, to rule out DNS rebinding.
+func Resolve(resolver Resolver) URLOption {
+	return func(o *urlOptions) {
+		o.resolver = resolver
+	}
+}
+
+// ParseURL parses an absolute URL for a server to call or redirect to,
+// such as a webhook or a redirect_uri. It rejects other schemes than http
+// and https, user info, and hosts that are IP addresses outside the
+// address policy, "localhost" or numeric names some resolvers treat as
+// addresses. Host names are lower cased and international labels are
+// punycode encoded (RFC 3492) to their xn-- form. The UTS 46 mapping of
+// IDNA is not applied, so a name like "ｅｘａｍｐｌｅ.com" is encoded as it
+// is instead of being folded to "example.com" and does not match it.
+func ParseURL(ctx context.Context, value string, opts ...URLOption) (*url.URL, error) {
+	o := urlOptions{schemes: []string{"http", "https"}, addrOpts: []NetOption{PublicOnly()}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsAbs() || len(u.Host) == 0 || len(u.Opaque) > 0 {
+		return nil, fmt.Errorf("%w: %q is not an absolute URL", ErrForbiddenURL, value)
+	}
+	if !containsFold(o.schemes, u.Scheme) {
+		return nil, fmt.Errorf("%w: scheme %q", ErrForbiddenURL, u.Scheme)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("%w: user info", ErrForbiddenURL)
+	}
+
+	host := u.Hostname()
+	policy := netPolicy(o.addrOpts)
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if err := policy.check(netip.PrefixFrom(addr.WithZone(""), addr.BitLen()), addr.Zone()); err != nil {
+			return nil, err
+		}
+		host = addr.String()
+	} else {
+		if host, err = normalizeHost(host); err != nil {
+			return nil, err
+		}
+		if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+			return nil, fmt.Errorf("%w: host %q", ErrForbiddenURL, host)
+		}
+	}
+
+	if matchHosts(o.deny, host) || (o.allow != nil && !matchHosts(o.allow, host)) {
+		return nil, fmt.Errorf("%w: host %q", ErrForbiddenURL, host)
+	}
+
+	if o.resolver != nil {
+		addrs, err := o.resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("%w: host %q has no addresses", ErrForbiddenURL, host)
+		}
+		for _, addr := range addrs {
+			if err := policy.check(netip.PrefixFrom(addr.WithZone(""), addr.BitLen()), addr.Zone()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	out := *u
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port := u.Port(); len(port) > 0 {
+		host += ":" + port
+	}
+	out.Host = host
+	return &out, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHosts reports whether host matches one of patterns
+func matchHosts(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if strings.HasPrefix(pattern, "*.") {
+			if strings.HasSuffix(host, pattern[1:]) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHost lower cases a host name, converts international labels to
+// punycode and checks the labels are valid
+func normalizeHost(host string) (string, error) {
+	host = strings.NewReplacer("\u3002", ".", "\uff0e", ".", "\uff61", ".").Replace(strings.ToLower(host))
+	host = strings.TrimSuffix(host, ".")
+
+	labels := strings.Split(host, ".")
+	for index, label := range labels {
+		if !isASCII(label) {
+			encoded, err := punycode(label)
+			if err != nil {
+				return "", err
+			}
+			label = "xn--" + encoded
+			labels[index] = label
+		}
+		if !validLabel(label) {
+			return "", fmt.Errorf("%w: invalid host %q", ErrForbiddenURL, host)
+		}
+	}
+
+	// names like "2130706433" or "0x7f.1" are addresses to some resolvers,
+	// no top level domain is numeric
+	last := labels[len(labels)-1]
+	if allDigits(last) || strings.HasPrefix(last, "0x") {
+		return "", fmt.Errorf("%w: numeric host %q", ErrForbiddenURL, host)
+	}
+
+	host = strings.Join(labels, ".")
+	if len(host) > 253 {
+		return "", fmt.Errorf("%w: host is too long", ErrForbiddenURL)
+	}
+	return host, nil
+}
+
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// validLabel reports whether label is a letters, digits and hyphens label
+func validLabel(label string) bool {
+	if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z' || isDigit(c) || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// punycode encodes a label as described in RFC 3492
+func punycode(label string) (string, error) {
+	const (
+		base        = 36
+		tmin        = 1
+		tmax        = 26
+		skew        = 38
+		damp        = 700
+		initialBias = 72
+		initialN    = 128
+		maxDelta    = 1<<31 - 1
+	)
+	adapt := func(delta, points int, first bool) int {
+		if first {
+			delta /= damp
+		} else {
+			delta /= 2
+		}
+		delta += delta / points
+		k := 0
+		for delta > ((base-tmin)*tmax)/2 {
+			delta /= base - tmin
+			k += base
+		}
+		return k + (base-tmin+1)*delta/(delta+skew)
+	}
+	digit := func(d int) byte {
+		if d < 26 {
+			return byte('a' + d)
+		}
+		return byte('0' + d - 26)
+	}
+
+	runes := []rune(label)
+	var out []byte
+	for _, r := range runes {
+		if r < 0x80 {
+			out = append(out, byte(r))
+		}
+	}
+	basic := len(out)
+	if basic > 0 {
+		out = append(out, '-')
+	}
+
+	n, delta, bias, handled := initialN, 0, initialBias, basic
+	for handled < len(runes) {
+		m := maxDelta
+		for _, r := range runes {
+			if int(r) >= n && int(r) < m {
+				m = int(r)
+			}
+		}
+		if (m - n) > (maxDelta-delta)/(handled+1) {
+			return "", fmt.Errorf("%w: host label is too long", ErrForbiddenURL)
+		}
+		delta += (m - n) * (handled + 1)
+		n = m
+		for _, r := range runes {
+			if int(r) < n {
+				delta++
+			}
+			if int(r) != n {
+				continue
+			}
+			q := delta
+			for k := base; ; k += base {
+				t := k - bias
+				if t < tmin {
+					t = tmin
+				} else if t > tmax {
+					t = tmax
+				}
+				if q < t {
+					break
+				}
+				out = append(out, digit(t+(q-t)%(base-t)))
+				q = (q - t) / (base - t)
+			}
+			out = append(out, digit(q))
+			bias = adapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	return string(out), nil
+}
+
+// QueryURLArray returns a slice of query parameters as URLs, see ParseURL
+func QueryURLArray(r *http.Request, key string, opts ...URLOption) ([]*url.URL, error) {
+	return queryIDArray(r, key, func(value string) (*url.URL, error) {
+		return ParseURL(r.Context(), value, opts...)
+	})
+}
+
+// QueryURL returns a query parameter as a URL, see ParseURL
+func QueryURL(r *http.Request, key string, opts ...URLOption) (*url.URL, error) {
+	values, err := QueryURLArray(r, key, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}